@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+type BasicWorklogCache struct {
+	filePath string
+}
+
+func NewBasicWorklogCache() *BasicWorklogCache {
+	dirname, err := os.UserHomeDir()
+	if err != nil {
+		dirname = os.TempDir()
+	}
+	return &BasicWorklogCache{filePath: filepath.Join(dirname, cacheDirectoryName, worklogsFileName)}
+}
+
+// Load returns cached worklogs or nil if nothing was cached yet.
+func (c *BasicWorklogCache) Load() (*Worklogs, error) {
+	file, err := os.Open(c.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var worklogs Worklogs
+	if err := json.NewDecoder(file).Decode(&worklogs); err != nil {
+		return nil, err
+	}
+	if worklogs.Entries == nil {
+		worklogs.Entries = map[string]Worklog{}
+	}
+	return &worklogs, nil
+}
+
+func (c *BasicWorklogCache) Save(w *Worklogs) error {
+	// cache reveals issues user works on, only the owner may read it
+	if err := os.MkdirAll(filepath.Dir(c.filePath), 0700); err != nil {
+		return err
+	}
+	tmpPath := c.filePath + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	// mode isn't applied to a file left behind by interrupted save
+	if err := file.Chmod(0600); err != nil {
+		file.Close()
+		return err
+	}
+	if err := json.NewEncoder(file).Encode(w); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, c.filePath)
+}
@@ -0,0 +1,48 @@
+package cache
+
+import "time"
+
+// WorklogCache persists worklogs of the current user between logit runs.
+type WorklogCache interface {
+	Load() (*Worklogs, error)
+	Save(w *Worklogs) error
+}
+
+// WorklogsVersion changes whenever cached data changes shape, caches saved
+// with a different version have to be rebuilt.
+const WorklogsVersion = 1
+
+// Worklogs is a snapshot of user's worklogs. It is complete for every day since
+// CoveredFrom and kept up to date with changes Jira reported until Since.
+type Worklogs struct {
+	Version int `json:"version"`
+	// Owner identifies Jira instance and user the cache was built for.
+	Owner       string             `json:"owner"`
+	CoveredFrom time.Time          `json:"covered_from"`
+	Since       time.Time          `json:"since"`
+	Entries     map[string]Worklog `json:"entries"`
+}
+
+type Worklog struct {
+	ID               string    `json:"id"`
+	IssueID          string    `json:"issue_id"`
+	IssueKey         string    `json:"issue_key"`
+	IssueSummary     string    `json:"issue_summary"`
+	Started          time.Time `json:"started"`
+	TimeSpentSeconds int       `json:"time_spent_seconds"`
+	// Visibility is a restriction like "role:Developers", empty when worklog is visible to everyone.
+	Visibility string `json:"visibility,omitempty"`
+}
+
+func NewWorklogs(owner string, coveredFrom, since time.Time) *Worklogs {
+	return &Worklogs{
+		Version:     WorklogsVersion,
+		Owner:       owner,
+		CoveredFrom: coveredFrom,
+		Since:       since,
+		Entries:     map[string]Worklog{},
+	}
+}
+
+const cacheDirectoryName = ".logit"
+const worklogsFileName = "worklogs.json"
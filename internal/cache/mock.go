@@ -0,0 +1,24 @@
+package cache
+
+type MockWorklogCache struct {
+	Worklogs *Worklogs
+	Saves    int
+	Err      error
+}
+
+func NewMockWorklogCache() *MockWorklogCache {
+	return &MockWorklogCache{}
+}
+
+func (c *MockWorklogCache) Load() (*Worklogs, error) {
+	return c.Worklogs, c.Err
+}
+
+func (c *MockWorklogCache) Save(w *Worklogs) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	c.Worklogs = w
+	c.Saves++
+	return nil
+}
@@ -4,12 +4,13 @@ import (
 	"fmt"
 
 	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/jira"
 	"github.com/FilipFl/logit/internal/prompter"
 	"github.com/spf13/cobra"
 )
 
 func NewSetAliasCommand(config configuration.Config, prompter prompter.Prompter) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "set [alias] [taskKey]",
 		Short: "Set an alias for a Jira task",
 		Args:  cobra.ExactArgs(2),
@@ -19,6 +20,13 @@ func NewSetAliasCommand(config configuration.Config, prompter prompter.Prompter)
 				fmt.Println(err)
 				return
 			}
+			visibility, _ := cmd.Flags().GetString("visibility")
+			if visibility != "" {
+				if _, err := jira.ParseVisibility(visibility); err != nil {
+					fmt.Println("Error setting an alias:", err)
+					return
+				}
+			}
 			oldTaskKey, _ := config.GetTaskFromAlias(args[0])
 			if oldTaskKey != "" {
 				approve, err := prompter.PromptForApprove(fmt.Sprintf("Are You sure You want to overwrite alias %s: %s with task %s", args[0], oldTaskKey, taskKey))
@@ -35,9 +43,18 @@ func NewSetAliasCommand(config configuration.Config, prompter prompter.Prompter)
 				fmt.Println("Failed setting alias:", err)
 				return
 			}
+			if cmd.Flags().Changed("visibility") {
+				err = config.SetAliasVisibility(args[0], visibility)
+				if err != nil {
+					fmt.Println("Failed setting alias visibility:", err)
+					return
+				}
+			}
 			fmt.Printf("Alias %s set for task %s\n", args[0], taskKey)
 		},
 	}
+	cmd.Flags().String("visibility", "", "Default visibility of work logged with alias, role:name or group:name (empty string removes it)")
+	return cmd
 }
 
 func NewRemoveAliasCommand(config configuration.Config) *cobra.Command {
@@ -63,6 +80,10 @@ func NewListAliasesCommand(config configuration.Config) *cobra.Command {
 		Args:  nil,
 		Run: func(cmd *cobra.Command, args []string) {
 			for k, v := range config.GetAliases() {
+				if visibility := config.GetAliasVisibility(k); visibility != "" {
+					fmt.Printf("%s: %s (visible to %s) \n", k, v, visibility)
+					continue
+				}
 				fmt.Printf("%s: %s \n", k, v)
 			}
 		},
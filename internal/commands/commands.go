@@ -1,11 +1,14 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"runtime"
+	"strings"
 
 	"text/tabwriter"
 
@@ -13,6 +16,7 @@ import (
 	"github.com/FilipFl/logit/internal/git"
 	"github.com/FilipFl/logit/internal/jira"
 	"github.com/FilipFl/logit/internal/printer"
+	"github.com/FilipFl/logit/internal/progress"
 	"github.com/FilipFl/logit/internal/prompter"
 	"github.com/FilipFl/logit/internal/timer"
 	"github.com/spf13/cobra"
@@ -50,7 +54,7 @@ func NewOpenCommand(cfg configuration.Config, prompter prompter.Prompter, gitHan
 				}
 			}
 			if task == "" {
-				task, err = determineTask(cmd, cfg, prompter, gitHandler, true)
+				task, err = determineTask(cmd, cfg, prompter, gitHandler, nil, true)
 				if err != nil {
 					fmt.Println("Failed to open task:", err)
 					return
@@ -78,34 +82,85 @@ func NewOpenCommand(cfg configuration.Config, prompter prompter.Prompter, gitHan
 	}
 	cmd.Flags().StringP("task", "t", "", "Jira task ID or URL")
 	cmd.Flags().StringP("alias", "a", "", "Task by alias")
-	cmd.RegisterFlagCompletionFunc("alias", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		aliases := []string{}
-		for alias := range cfg.GetAliases() {
-			aliases = append(aliases, alias)
+	cmd.RegisterFlagCompletionFunc("alias", aliasCompletion(cfg))
+	return cmd
+}
+
+func NewMyTasksCommand(cfg configuration.Config, client jira.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tasks",
+		Short: "List tasks assigned to me (query is configurable with `logit config set-tasks-jql`)",
+		Args:  nil,
+		Run: func(cmd *cobra.Command, args []string) {
+			var results []jira.Issue
+			var err error
+			if query, _ := cmd.Flags().GetString("query"); query != "" {
+				jql, queryErr := cfg.GetQuery(query)
+				if queryErr != nil {
+					fmt.Printf("Query %s not found on queries list\n", query)
+					return
+				}
+				results, err = client.SearchIssues(cmd.Context(), jql)
+			} else {
+				results, err = client.GetAssignedIssues(cmd.Context())
+			}
+			if err != nil {
+				fmt.Println("Error fetching assigned tasks:", explainJiraError(err, "search issues", ""))
+				return
+			}
+			printIssues(results)
+		},
+	}
+	cmd.Flags().StringP("query", "q", "", "List tasks matching saved query instead")
+	cmd.RegisterFlagCompletionFunc("query", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		queries := []string{}
+		for query := range cfg.GetQueries() {
+			queries = append(queries, query)
 		}
-		return aliases, cobra.ShellCompDirectiveNoFileComp
+		return queries, cobra.ShellCompDirectiveNoFileComp
 	})
 	return cmd
 }
 
-func NewMyTasksCommand(client jira.Client) *cobra.Command {
-	return &cobra.Command{
-		Use:   "tasks",
-		Short: "List tasks assigned to me",
+func printIssues(issues []jira.Issue) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.Debug)
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s\t%s\t%s\t\n", issue.Key, truncateString(issue.Summary, 37), issue.Status)
+	}
+	w.Flush()
+}
+
+func NewWhoAmICommand(client jira.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show Jira user logit is authenticated as",
 		Args:  nil,
 		Run: func(cmd *cobra.Command, args []string) {
-			results, err := client.GetAssignedIssues()
+			refresh, _ := cmd.Flags().GetBool("refresh")
+			user, err := client.GetCurrentUser(cmd.Context(), refresh)
 			if err != nil {
-				fmt.Println("Error fetching assigned tasks:", err)
+				fmt.Println("Error identifying current user:", explainJiraError(err, "identify current user", ""))
 				return
 			}
-			w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.Debug)
-			for _, issue := range results {
-				fmt.Fprintf(w, "%s\t%s\t%s\t\n", issue.Key, truncateString(issue.Summary, 37), issue.Status)
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+			fmt.Fprintf(w, "Display name:\t%s\n", user.DisplayName)
+			if user.Name != "" {
+				fmt.Fprintf(w, "Username:\t%s\n", user.Name)
+			}
+			if user.Key != "" {
+				fmt.Fprintf(w, "Key:\t%s\n", user.Key)
+			}
+			if user.AccountID != "" {
+				fmt.Fprintf(w, "Account ID:\t%s\n", user.AccountID)
+			}
+			if user.EmailAddress != "" {
+				fmt.Fprintf(w, "Email:\t%s\n", user.EmailAddress)
 			}
 			w.Flush()
 		},
 	}
+	cmd.Flags().BoolP("refresh", "r", false, "Ask Jira instead of using user cached in config")
+	return cmd
 }
 
 func NewMyWorklogsCommand(client jira.Client) *cobra.Command {
@@ -120,29 +175,40 @@ func NewMyWorklogsCommand(client jira.Client) *cobra.Command {
 				return
 			}
 			fromDays := worklogsFromHowManyDays(cmd)
-			results, err := client.GetLoggedTime(fromDays)
+			var reporter progress.Reporter = progress.NewBasicReporter(os.Stderr, "Fetching worklogs of tasks")
+			if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
+				reporter = progress.NewSilentReporter()
+			}
+			refresh, _ := cmd.Flags().GetBool("refresh")
+			results, err := client.GetLoggedTime(cmd.Context(), jira.LoggedTimeQuery{
+				FromDays: fromDays,
+				Refresh:  refresh,
+				Reporter: reporter,
+			})
+			var partialErr *jira.PartialResultError
+			if errors.As(err, &partialErr) {
+				printWorklogs(results)
+				fmt.Fprintln(os.Stderr, "Worklogs are incomplete:", partialErr)
+				fmt.Fprintln(os.Stderr, partialErr.Diagnostics())
+				return
+			}
 			if err != nil {
-				fmt.Println("Error fetching assigned tasks:", err)
+				fmt.Println("Error fetching worklogs:", explainJiraError(err, "search worklogs", ""))
 				return
 			}
 			if len(results.Days) == 0 {
 				fmt.Println("no time logged in specified time range")
 				return
 			}
-			for _, day := range results.Days {
-				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.Debug)
-				for _, log := range day.Worklogs {
-					fmt.Fprintf(w, "%s\t%s\t%s\t\n", log.TaskKey, log.StringLoggedTime(), truncateString(log.Summary, 40))
-				}
-				printer.PrintGreen(fmt.Sprintf("%s (%s) - %dh %dm\n", day.DateString(), day.Date.Weekday(), int(day.TimeLogged.Hours()), int(day.TimeLogged.Minutes())%60))
-				w.Flush()
-			}
+			printWorklogs(results)
 		},
 	}
 	cmd.Flags().BoolP("today", "t", false, "Return worklogs from today")
 	cmd.Flags().BoolP("yesterday", "y", false, "Return worklogs from yesterday and today")
 	cmd.Flags().BoolP("week", "w", false, "Return worklogs from last week")
 	cmd.Flags().IntP("days", "d", 0, "Return worklogs from X days (X must be less or equal than 14)")
+	cmd.Flags().BoolP("quiet", "q", false, "Don't report progress of fetching worklogs")
+	cmd.Flags().Bool("refresh", false, "Rebuild local worklog cache from scratch")
 	return cmd
 }
 
@@ -156,9 +222,24 @@ func NewLogCommand(cfg configuration.Config, prompter prompter.Prompter, gitHand
 				fmt.Println("Error validating flags:", err)
 				return
 			}
+			adjustment := estimateAdjustmentFromFlags(cmd, "reduce-by")
+			if err := adjustment.Validate(); err != nil {
+				fmt.Println("Error validating flags:", err)
+				return
+			}
+			visibility, err := determineVisibility(cmd, cfg)
+			if err != nil {
+				fmt.Println("Error validating flags:", err)
+				return
+			}
+			attributes, err := parseWorkAttributes(cmd)
+			if err != nil {
+				fmt.Println("Error validating flags:", err)
+				return
+			}
 
 			force, _ := cmd.Flags().GetBool("force")
-			task, err := determineTask(cmd, cfg, prompter, gitHandler, force)
+			task, err := determineTask(cmd, cfg, prompter, gitHandler, client, force)
 			if err != nil {
 				fmt.Println("Error assessing task to log time:", err)
 				return
@@ -179,10 +260,28 @@ func NewLogCommand(cfg configuration.Config, prompter prompter.Prompter, gitHand
 			}
 
 			comment, _ := cmd.Flags().GetString("comment")
-			if err := client.LogTime(task, duration, dateStarted, comment); err != nil {
-				fmt.Println("Error logging time:", err)
+			options := jira.LogTimeOptions{Adjustment: adjustment, Visibility: visibility, Attributes: attributes}
+			worklogID, err := client.LogTime(cmd.Context(), task, duration, dateStarted, comment, options)
+			if err != nil {
+				fmt.Println("Error logging time:", explainJiraError(err, "log work on", task))
 			} else {
 				fmt.Printf("Successfully logged %dh %dm for task %s\n", int(duration.Hours()), int(duration.Minutes())%60, task)
+				if visibility != nil {
+					fmt.Printf("Worklog is visible only to %s\n", visibility)
+				}
+				printRemainingEstimate(cmd.Context(), client, task)
+				if query, _ := cmd.Flags().GetString("transition"); query != "" {
+					transition, err := transitionTask(cmd.Context(), client, prompter, task, query)
+					if err != nil {
+						fmt.Println("Error transitioning task:", explainJiraError(err, "transition", task))
+					} else {
+						fmt.Printf("Task %s moved to %s\n", task, transitionTarget(transition))
+					}
+				}
+				if worklogID != "" {
+					// only needed by `worklog edit --last`, logging itself succeeded
+					cfg.SetLastWorklog(&configuration.LastWorklog{TaskKey: task, WorklogID: worklogID})
+				}
 				reset, _ := cmd.Flags().GetBool("reset")
 				if fromSnapshot || reset {
 					now := timer.Now()
@@ -204,12 +303,43 @@ func NewLogCommand(cfg configuration.Config, prompter prompter.Prompter, gitHand
 	cmd.Flags().StringP("date", "d", "", "Date in format dd-mm, present year is assumed")
 	cmd.Flags().BoolP("reset", "r", false, "Reset snapshot")
 	cmd.Flags().BoolP("force", "f", false, "Force approve when prompted")
-	cmd.RegisterFlagCompletionFunc("alias", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		aliases := []string{}
-		for alias := range cfg.GetAliases() {
-			aliases = append(aliases, alias)
-		}
-		return aliases, cobra.ShellCompDirectiveNoFileComp
-	})
+	addEstimateAdjustmentFlags(cmd, "reduce-by", "Time to subtract from remaining estimate in manual mode, e.g. 1h 30m")
+	cmd.Flags().String("visibility", "", "Restrict worklog to role:name or group:name, overrides default visibility of alias")
+	cmd.Flags().String("transition", "", "Move task to status matching this transition after logging time")
+	cmd.Flags().StringArray("attribute", nil, "Tempo work attribute as name=value, can be repeated (Tempo backend only)")
+	cmd.RegisterFlagCompletionFunc("alias", aliasCompletion(cfg))
 	return cmd
 }
+
+// printRemainingEstimate shows how much work is left on task after logging time.
+// Logging already succeeded, so failure is reported without making a fuss.
+func printRemainingEstimate(ctx context.Context, client jira.Client, task string) {
+	timeTracking, err := client.GetTimeTracking(ctx, task)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Couldn't fetch remaining estimate:", explainJiraError(err, "read estimate of", task))
+		return
+	}
+	if timeTracking.RemainingEstimate == "" {
+		fmt.Printf("Task %s has no remaining estimate set\n", task)
+		return
+	}
+	fmt.Printf("Remaining estimate of task %s: %s\n", task, timeTracking.RemainingEstimate)
+}
+
+func restrictionNote(visibilities []string) string {
+	if len(visibilities) == 0 {
+		return ""
+	}
+	return " restricted to " + strings.Join(visibilities, ", ")
+}
+
+func printWorklogs(results jira.Logs) {
+	for _, day := range results.Days {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.Debug)
+		for _, log := range day.Worklogs {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", log.TaskKey, log.StringLoggedTime(), truncateString(log.Summary, 40), restrictionNote(log.Visibilities))
+		}
+		printer.PrintGreen(fmt.Sprintf("%s (%s) - %dh %dm\n", day.DateString(), day.Date.Weekday(), int(day.TimeLogged.Hours()), int(day.TimeLogged.Minutes())%60))
+		w.Flush()
+	}
+}
@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/git"
+	"github.com/FilipFl/logit/internal/jira"
+	"github.com/FilipFl/logit/internal/prompter"
+	"github.com/FilipFl/logit/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+const defaultEditor = "vi"
+
+func NewCommentCommand(cfg configuration.Config, prompter prompter.Prompter, gitHandler git.GitHandler, timer timer.Timer, client jira.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment [alias | taskKey]",
+		Short: "Comment on task, text is taken from flag, stdin or $EDITOR",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			withWorklog, _ := cmd.Flags().GetBool("with-worklog")
+			if withWorklog {
+				if err := assertFlagsAreValid(cmd, timer); err != nil {
+					fmt.Println("Error validating flags:", err)
+					return
+				}
+			}
+			visibility, err := determineVisibility(cmd, cfg)
+			if err != nil {
+				fmt.Println("Error validating flags:", err)
+				return
+			}
+
+			task := ""
+			if len(args) > 0 {
+				task = taskFromArg(cfg, args[0])
+			}
+			if task == "" {
+				force, _ := cmd.Flags().GetBool("force")
+				task, err = determineTask(cmd, cfg, prompter, gitHandler, client, force)
+				if err != nil {
+					fmt.Println("Error assessing task to comment:", err)
+					return
+				}
+			}
+
+			// duration is settled before editor opens, so that time spent writing isn't logged
+			var duration time.Duration
+			var fromSnapshot bool
+			if withWorklog {
+				duration, fromSnapshot, err = parseDuration(cmd, cfg, prompter, timer)
+				if err != nil {
+					fmt.Println("Invalid log work duration:", err)
+					return
+				}
+			}
+			text, err := readCommentText(cmd, task)
+			if err != nil {
+				fmt.Println("Error reading comment:", err)
+				return
+			}
+
+			if withWorklog {
+				started, err := determineStarted(cmd, timer)
+				if err != nil {
+					fmt.Println("Error assessing date to log time on:", err)
+					return
+				}
+				options := jira.LogTimeOptions{Visibility: visibility}
+				worklogID, err := client.LogTime(cmd.Context(), task, duration, started, text, options)
+				if err != nil {
+					fmt.Println("Error logging time:", explainJiraError(err, "log work on", task))
+					return
+				}
+				fmt.Printf("Successfully logged %s for task %s\n", formatDuration(duration), task)
+				if worklogID != "" {
+					cfg.SetLastWorklog(&configuration.LastWorklog{TaskKey: task, WorklogID: worklogID})
+				}
+				if fromSnapshot {
+					now := timer.Now()
+					if err := cfg.SetSnapshot(&now); err != nil {
+						fmt.Println("Failed starting to measure time:", err)
+					}
+				}
+			}
+
+			_, err = client.AddComment(cmd.Context(), task, text, visibility)
+			if err != nil {
+				fmt.Println("Error adding comment:", explainJiraError(err, "comment on", task))
+				return
+			}
+			fmt.Printf("Successfully commented on task %s\n", task)
+		},
+	}
+	cmd.Flags().String("text", "", "Comment text, without it text is read from stdin or $EDITOR")
+	cmd.Flags().String("visibility", "", "Restrict comment to role:name or group:name, overrides default visibility of alias")
+	cmd.Flags().StringP("task", "t", "", "Jira task ID or URL")
+	cmd.Flags().StringP("alias", "a", "", "Task by alias")
+	cmd.Flags().BoolP("force", "f", false, "Force approve when prompted")
+	cmd.Flags().Bool("with-worklog", false, "Also log time with the same text as worklog comment")
+	cmd.Flags().IntP("hours", "H", 0, "Hours spent (with --with-worklog)")
+	cmd.Flags().IntP("minutes", "m", 0, "Minutes spent (with --with-worklog)")
+	cmd.Flags().BoolP("yesterday", "y", false, "Log time for yesterday (with --with-worklog)")
+	cmd.Flags().StringP("date", "d", "", "Log time for date in format dd-mm (with --with-worklog)")
+	cmd.RegisterFlagCompletionFunc("alias", aliasCompletion(cfg))
+	return cmd
+}
+
+// readCommentText takes comment from text flag, from stdin when something is
+// piped to it or lets user write it in editor.
+func readCommentText(cmd *cobra.Command, task string) (string, error) {
+	text, _ := cmd.Flags().GetString("text")
+	if !cmd.Flags().Changed("text") {
+		var err error
+		if isTerminal(cmd.InOrStdin()) {
+			text, err = editText(task)
+		} else {
+			var data []byte
+			data, err = io.ReadAll(cmd.InOrStdin())
+			text = string(data)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", errorEmptyComment
+	}
+	return text, nil
+}
+
+func isTerminal(r io.Reader) bool {
+	file, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// editText opens $VISUAL or $EDITOR on a temporary file and returns what user saved.
+func editText(task string) (string, error) {
+	editor := editorCommand()
+	file, err := os.CreateTemp("", fmt.Sprintf("logit-%s-*.txt", task))
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	file.Close()
+
+	editorCmd := exec.Command(editor[0], append(editor[1:], file.Name())...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %s failed: %w", strings.Join(editor, " "), err)
+	}
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// editorCommand returns editor from VISUAL or EDITOR split into command and
+// its arguments, e.g. "code --wait". Blank variables count as unset.
+func editorCommand() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if parts := strings.Fields(os.Getenv(name)); len(parts) > 0 {
+			return parts
+		}
+	}
+	return []string{defaultEditor}
+}
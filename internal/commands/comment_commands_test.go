@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCommentText(t *testing.T) {
+	tests := []struct {
+		name          string
+		textFlag      *string
+		stdin         string
+		expectedText  string
+		expectedError error
+	}{
+		{
+			name:         "FromFlag",
+			textFlag:     ptr("  Fixed in 1.2 "),
+			stdin:        "ignored",
+			expectedText: "Fixed in 1.2",
+		},
+		{
+			name:         "FromStdin",
+			stdin:        "Line one\nLine two\n",
+			expectedText: "Line one\nLine two",
+		},
+		{
+			name:          "Empty",
+			stdin:         "  \n",
+			expectedError: errorEmptyComment,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("text", "", "")
+			if tt.textFlag != nil {
+				cmd.Flags().Set("text", *tt.textFlag)
+			}
+			cmd.SetIn(strings.NewReader(tt.stdin))
+
+			text, err := readCommentText(cmd, "PROJ-1")
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedText, text)
+		})
+	}
+}
+
+func TestEditText(t *testing.T) {
+	editor := filepath.Join(t.TempDir(), "editor.sh")
+	err := os.WriteFile(editor, []byte("#!/bin/sh\necho 'Written in editor' > \"$1\"\n"), 0o755)
+	assert.NoError(t, err)
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", editor)
+
+	text, err := editText("PROJ-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Written in editor\n", text)
+}
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		visual   string
+		editor   string
+		expected []string
+	}{
+		{name: "Unset", expected: []string{defaultEditor}},
+		{name: "Blank", visual: " ", editor: "\t", expected: []string{defaultEditor}},
+		{name: "BlankVisual", visual: " ", editor: "code --wait", expected: []string{"code", "--wait"}},
+		{name: "VisualFirst", visual: "vim", editor: "nano", expected: []string{"vim"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", tt.visual)
+			t.Setenv("EDITOR", tt.editor)
+			assert.Equal(t, tt.expected, editorCommand())
+		})
+	}
+}
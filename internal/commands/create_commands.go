@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/jira"
+	"github.com/FilipFl/logit/internal/prompter"
+	"github.com/FilipFl/logit/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+const defaultIssueType = "Task"
+
+func NewCreateCommand(cfg configuration.Config, prompter prompter.Prompter, timer timer.Timer, client jira.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create task or sub-task, optionally log time to it right away",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			issue, err := newIssueFromFlags(cmd, cfg)
+			if err != nil {
+				fmt.Println("Error validating flags:", err)
+				return
+			}
+			duration, err := parseLogFlag(cmd)
+			if err != nil {
+				fmt.Println("Error validating flags:", err)
+				return
+			}
+			if issue.Summary == "" {
+				issue.Summary, err = prompter.PromptForString("", "Enter summary:")
+				if err != nil {
+					fmt.Println("Error creating task:", err)
+					return
+				}
+				if issue.Summary = strings.TrimSpace(issue.Summary); issue.Summary == "" {
+					fmt.Println("Error creating task:", errorEmptySummary)
+					return
+				}
+			}
+
+			typeName, _ := cmd.Flags().GetString("type")
+			if typeName == "" && issue.ParentKey == "" {
+				typeName = defaultIssueType
+			}
+			issueType, err := client.GetIssueType(cmd.Context(), issue.ProjectKey, typeName, issue.ParentKey != "")
+			if err != nil {
+				fmt.Println("Error reading issue types:", explainJiraError(err, "create issues in project", issue.ProjectKey))
+				return
+			}
+			issue.IssueTypeID = issueType.ID
+			issue.Fields, err = promptRequiredFields(prompter, issueType.Name, issueType.Fields, presetCreateFields(issue))
+			if err != nil {
+				fmt.Println("Error creating task:", err)
+				return
+			}
+			task, err := client.CreateIssue(cmd.Context(), issue)
+			if err != nil {
+				fmt.Println("Error creating task:", explainJiraError(err, "create issues in project", issue.ProjectKey))
+				return
+			}
+			fmt.Printf("Created %s %s: %s\n", issueType.Name, task, issue.Summary)
+
+			if duration > 0 {
+				comment, _ := cmd.Flags().GetString("comment")
+				worklogID, err := client.LogTime(cmd.Context(), task, duration, timer.Now(), comment, jira.LogTimeOptions{})
+				if err != nil {
+					fmt.Println("Error logging time:", explainJiraError(err, "log work on", task))
+				} else {
+					fmt.Printf("Successfully logged %s for task %s\n", formatDuration(duration), task)
+					if worklogID != "" {
+						cfg.SetLastWorklog(&configuration.LastWorklog{TaskKey: task, WorklogID: worklogID})
+					}
+				}
+			}
+
+			if err := offerAlias(cmd, cfg, prompter, task); err != nil {
+				fmt.Println("Failed setting alias:", err)
+			}
+		},
+	}
+	cmd.Flags().StringP("project", "p", "", "Project key, taken from parent when omitted")
+	cmd.Flags().String("type", "", "Issue type, defaults to Task or to sub-task type when parent is set")
+	cmd.Flags().String("parent", "", "Parent task key, URL or alias, creates a sub-task")
+	cmd.Flags().StringP("summary", "s", "", "Task summary")
+	cmd.Flags().String("description", "", "Task description")
+	cmd.Flags().String("log", "", "Log time to created task right away (e.g. 1h30m)")
+	cmd.Flags().StringP("comment", "c", "", "Comment of worklog logged with --log")
+	cmd.Flags().String("save-alias", "", "Save alias for created task")
+	cmd.Flags().Bool("no-alias", false, "Don't offer to save alias")
+	return cmd
+}
+
+func newIssueFromFlags(cmd *cobra.Command, cfg configuration.Config) (jira.NewIssue, error) {
+	project, _ := cmd.Flags().GetString("project")
+	parent, _ := cmd.Flags().GetString("parent")
+	summary, _ := cmd.Flags().GetString("summary")
+	description, _ := cmd.Flags().GetString("description")
+	issue := jira.NewIssue{
+		ProjectKey:  strings.ToUpper(strings.TrimSpace(project)),
+		Summary:     strings.TrimSpace(summary),
+		Description: strings.TrimSpace(description),
+	}
+	if parent != "" {
+		issue.ParentKey = taskFromArg(cfg, parent)
+		if issue.ParentKey == "" {
+			return jira.NewIssue{}, fmt.Errorf("parent: %w", errorNoJiraTask)
+		}
+		parentProject, _, _ := strings.Cut(issue.ParentKey, "-")
+		if issue.ProjectKey == "" {
+			issue.ProjectKey = parentProject
+		}
+	}
+	if issue.ProjectKey == "" {
+		return jira.NewIssue{}, errorProjectRequired
+	}
+	return issue, nil
+}
+
+func parseLogFlag(cmd *cobra.Command) (time.Duration, error) {
+	log, _ := cmd.Flags().GetString("log")
+	if log == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(strings.ReplaceAll(log, " ", ""))
+	if err != nil || duration < time.Minute {
+		return 0, errorWrongDuration
+	}
+	return duration, nil
+}
+
+// presetCreateFields lists fields logit fills itself, so user isn't asked for them.
+func presetCreateFields(issue jira.NewIssue) map[string]bool {
+	set := map[string]bool{"project": true, "issuetype": true, "summary": true}
+	if issue.ParentKey != "" {
+		set["parent"] = true
+	}
+	if issue.Description != "" {
+		set["description"] = true
+	}
+	return set
+}
+
+// offerAlias saves alias passed with save-alias flag or asks whether to save one.
+func offerAlias(cmd *cobra.Command, cfg configuration.Config, prompter prompter.Prompter, task string) error {
+	alias, _ := cmd.Flags().GetString("save-alias")
+	if alias == "" {
+		if noAlias, _ := cmd.Flags().GetBool("no-alias"); noAlias {
+			return nil
+		}
+		save, err := prompter.PromptForApprove(fmt.Sprintf("Do You want to save alias for task %s?", task))
+		if err != nil || !save {
+			return err
+		}
+		alias, err = prompter.PromptForString("", "Enter alias:")
+		if err != nil {
+			return err
+		}
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			return nil
+		}
+	}
+	if oldTask, _ := cfg.GetTaskFromAlias(alias); oldTask != "" {
+		approve, err := prompter.PromptForApprove(fmt.Sprintf("Are You sure You want to overwrite alias %s: %s with task %s", alias, oldTask, task))
+		if err != nil || !approve {
+			return err
+		}
+	}
+	if err := cfg.AddAlias(alias, task); err != nil {
+		return err
+	}
+	fmt.Printf("Alias %s set for task %s\n", alias, task)
+	return nil
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/jira"
+	"github.com/FilipFl/logit/internal/prompter"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIssueFromFlags(t *testing.T) {
+	tests := []struct {
+		name          string
+		project       string
+		parent        string
+		expected      jira.NewIssue
+		expectedError error
+	}{
+		{
+			name:     "Project",
+			project:  "abc",
+			expected: jira.NewIssue{ProjectKey: "ABC", Summary: "Fix flaky test"},
+		},
+		{
+			name:     "ProjectFromParent",
+			parent:   "https://jira.example.com/browse/ABC-12",
+			expected: jira.NewIssue{ProjectKey: "ABC", ParentKey: "ABC-12", Summary: "Fix flaky test"},
+		},
+		{
+			name:     "ParentByAlias",
+			parent:   "backend",
+			expected: jira.NewIssue{ProjectKey: "BE", ParentKey: "BE-7", Summary: "Fix flaky test"},
+		},
+		{
+			name:          "InvalidParent",
+			parent:        "nothing",
+			expectedError: errorNoJiraTask,
+		},
+		{
+			name:          "NoProject",
+			expectedError: errorProjectRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConfig := configuration.NewMockConfig(&configuration.Cfg{Aliases: map[string]string{"backend": "BE-7"}})
+			cmd := &cobra.Command{}
+			cmd.Flags().String("project", tt.project, "")
+			cmd.Flags().String("parent", tt.parent, "")
+			cmd.Flags().String("summary", " Fix flaky test ", "")
+			cmd.Flags().String("description", "", "")
+
+			issue, err := newIssueFromFlags(cmd, mockConfig)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, issue)
+		})
+	}
+}
+
+func TestParseLogFlag(t *testing.T) {
+	tests := []struct {
+		log              string
+		expectedDuration time.Duration
+		expectError      bool
+	}{
+		{log: "", expectedDuration: 0},
+		{log: "1h30m", expectedDuration: 90 * time.Minute},
+		{log: "1h 15m", expectedDuration: 75 * time.Minute},
+		{log: "30s", expectError: true},
+		{log: "soon", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.log, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("log", tt.log, "")
+
+			duration, err := parseLogFlag(cmd)
+			if tt.expectError {
+				assert.ErrorIs(t, err, errorWrongDuration)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedDuration, duration)
+		})
+	}
+}
+
+func TestPromptRequiredFields(t *testing.T) {
+	metas := map[string]jira.FieldMeta{
+		"summary":    {Name: "Summary", Required: true},
+		"components": {Name: "Component", Required: true, Schema: jira.FieldSchema{Type: "array"}, AllowedValues: []jira.AllowedValue{{ID: "10", Name: "Backend"}, {ID: "11", Name: "Frontend"}}},
+		"priority":   {Name: "Priority", Required: true, HasDefault: true},
+	}
+	mockPrompter := prompter.NewMockPrompter()
+	mockPrompter.SetStringResponses([]string{"2"}, nil)
+
+	fields, err := promptRequiredFields(mockPrompter, "Sub-task", metas, map[string]bool{"summary": true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"components": []any{map[string]string{"id": "11"}},
+	}, fields)
+}
+
+func TestOfferAlias(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		approves  []bool
+		expectErr bool
+	}{
+		{name: "SaveAliasFlag", args: []string{"--save-alias", "flaky"}},
+		{name: "NoAliasFlag", args: []string{"--no-alias"}},
+		{name: "OfferDeclined", approves: []bool{false}},
+		{name: "OfferWithoutAnswer", expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewCreateCommand(configuration.NewMockConfig(&configuration.Cfg{}), prompter.NewMockPrompter(), nil, nil)
+			assert.NoError(t, cmd.ParseFlags(tt.args))
+			mockPrompter := prompter.NewMockPrompter()
+			mockPrompter.SetApproveResponses(tt.approves, nil)
+
+			err := offerAlias(cmd, configuration.NewMockConfig(&configuration.Cfg{}), mockPrompter, "ABC-1")
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
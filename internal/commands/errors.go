@@ -16,3 +16,21 @@ var errorYesterdayAndDate = errors.New("yesterday and date flags are mutually ex
 var errorSnapshotNotToday = errors.New("unable to log time from snapshot for day other than today")
 var errorConflictingWorklogsFlags = errors.New("only one flag is allowed")
 var errorTooBigDayRange = errors.New("can fetch worklogs from max 14 days")
+var errorNothingToUpdate = errors.New("pass at least one of hours, minutes, new-date or comment flags")
+var errorConflictingWorklogSelection = errors.New("worklog ID, last flag and picking by task or date are mutually exclusive")
+var errorNoLastWorklog = errors.New("no worklog was logged from this machine yet")
+var errorNoWorklogsToPick = errors.New("no worklogs found")
+var errorInvalidChoice = errors.New("invalid number picked")
+var errorInvalidWorkAttribute = errors.New("work attribute must be in format name=value")
+var errorTimesheetRequiresTempo = errors.New("timesheets are available only with Tempo backend, switch it with `logit config set-backend tempo`")
+var errorInvalidPeriod = errors.New("invalid period; accepted format either yyyy-mm or yyyy-mm-dd")
+var errorNoMatchingTransition = errors.New("no transition matches")
+var errorEmptyComment = errors.New("comment is empty")
+var errorProjectRequired = errors.New("project flag is required when no parent is set")
+var errorEmptySummary = errors.New("summary is empty")
+var errorNegativeWorklogCount = errors.New("number of worklogs to show can't be negative")
+var errorQueryAndFilter = errors.New("query and filter flag are mutually exclusive")
+var errorNoSearchQuery = errors.New("pass JQL, name of saved query or filter flag")
+var errorBoardNotConfigured = errors.New("no board configured, set it with `logit config set-board [id]`")
+var errorNoActiveSprint = errors.New("board has no active sprint")
+var errorInvalidSprintsCount = errors.New("number of sprints must be positive")
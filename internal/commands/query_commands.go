@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/prompter"
+	"github.com/spf13/cobra"
+)
+
+func NewSetQueryCommand(config configuration.Config, prompter prompter.Prompter) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set [name] [jql]",
+		Short: "Save JQL query under a name usable with `logit search` and `logit tasks --query`",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			jql := strings.TrimSpace(args[1])
+			if jql == "" {
+				fmt.Println("Error saving query:", errorNoSearchQuery)
+				return
+			}
+			oldJQL, _ := config.GetQuery(args[0])
+			if oldJQL != "" {
+				approve, err := prompter.PromptForApprove(fmt.Sprintf("Are You sure You want to overwrite query %s: %s", args[0], oldJQL))
+				if err != nil {
+					fmt.Println("Error saving query:", err)
+					return
+				}
+				if !approve {
+					return
+				}
+			}
+			err := config.SetQuery(args[0], jql)
+			if err != nil {
+				fmt.Println("Failed saving query:", err)
+				return
+			}
+			fmt.Printf("Query %s saved\n", args[0])
+		},
+	}
+}
+
+func NewRemoveQueryCommand(config configuration.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove [name]",
+		Short: "Remove a saved query",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := config.RemoveQuery(args[0])
+			if err != nil {
+				fmt.Printf("Query %s not found on queries list\n", args[0])
+				return
+			}
+			fmt.Printf("Removed query %s from queries list\n", args[0])
+		},
+	}
+}
+
+func NewListQueriesCommand(config configuration.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists all saved queries",
+		Args:  nil,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Printf("tasks (default of `logit tasks`): %s \n", config.GetTasksJQL())
+			for name, jql := range config.GetQueries() {
+				fmt.Printf("%s: %s \n", name, jql)
+			}
+		},
+	}
+}
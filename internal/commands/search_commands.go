@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+func NewSearchCommand(cfg configuration.Config, client jira.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search [jql | query]",
+		Short: "List tasks matching JQL, saved query or Jira filter",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			jql, err := searchJQL(cmd, cfg, args)
+			if err != nil {
+				fmt.Println("Error validating arguments:", err)
+				return
+			}
+			if jql == "" {
+				filterName, _ := cmd.Flags().GetString("filter")
+				filter, err := client.GetFilter(cmd.Context(), filterName)
+				if err != nil {
+					fmt.Println("Error finding filter:", explainJiraError(err, "read filter", ""))
+					return
+				}
+				jql = filter.JQL
+			}
+			results, err := client.SearchIssues(cmd.Context(), jql)
+			if err != nil {
+				fmt.Println("Error searching tasks:", explainJiraError(err, "search issues", ""))
+				return
+			}
+			printIssues(results)
+		},
+	}
+	cmd.Flags().String("filter", "", "ID or name of favourite Jira filter to search with")
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		queries := []string{}
+		for query := range cfg.GetQueries() {
+			queries = append(queries, query)
+		}
+		return queries, cobra.ShellCompDirectiveNoFileComp
+	}
+	return cmd
+}
+
+// searchJQL returns JQL of saved query named by argument or argument itself.
+// Empty JQL means filter flag has to be resolved in Jira.
+func searchJQL(cmd *cobra.Command, cfg configuration.Config, args []string) (string, error) {
+	filter, _ := cmd.Flags().GetString("filter")
+	if len(args) > 0 && filter != "" {
+		return "", errorQueryAndFilter
+	}
+	if len(args) == 0 {
+		if filter == "" {
+			return "", errorNoSearchQuery
+		}
+		return "", nil
+	}
+	if jql, err := cfg.GetQuery(args[0]); err == nil && jql != "" {
+		return jql, nil
+	}
+	jql := strings.TrimSpace(args[0])
+	if jql == "" {
+		return "", errorNoSearchQuery
+	}
+	return jql, nil
+}
+
+func NewListFiltersCommand(client jira.Client) *cobra.Command {
+	return &cobra.Command{
+		Use:   "filters",
+		Short: "List favourite Jira filters usable with `logit search --filter`",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			filters, err := client.GetFavouriteFilters(cmd.Context())
+			if err != nil {
+				fmt.Println("Error fetching filters:", explainJiraError(err, "list filters", ""))
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.Debug)
+			for _, filter := range filters {
+				fmt.Fprintf(w, "%s\t%s\t%s\t\n", filter.ID, filter.Name, truncateString(filter.JQL, 60))
+			}
+			w.Flush()
+		},
+	}
+}
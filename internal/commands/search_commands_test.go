@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchJQL(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		filter        string
+		expectedJQL   string
+		expectedError error
+	}{
+		{
+			name:        "JQL",
+			args:        []string{"project = ABC ORDER BY created DESC"},
+			expectedJQL: "project = ABC ORDER BY created DESC",
+		},
+		{
+			name:        "SavedQuery",
+			args:        []string{"bugs"},
+			expectedJQL: "assignee = currentUser() AND type = Bug",
+		},
+		{
+			name:   "Filter",
+			filter: "10001",
+		},
+		{
+			name:          "QueryAndFilter",
+			args:          []string{"bugs"},
+			filter:        "10001",
+			expectedError: errorQueryAndFilter,
+		},
+		{
+			name:          "Nothing",
+			expectedError: errorNoSearchQuery,
+		},
+		{
+			name:          "Blank",
+			args:          []string{"  "},
+			expectedError: errorNoSearchQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConfig := configuration.NewMockConfig(&configuration.Cfg{
+				Queries: map[string]string{"bugs": "assignee = currentUser() AND type = Bug"},
+			})
+			cmd := &cobra.Command{}
+			cmd.Flags().String("filter", tt.filter, "")
+
+			jql, err := searchJQL(cmd, mockConfig, tt.args)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedJQL, jql)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/git"
+	"github.com/FilipFl/logit/internal/jira"
+	"github.com/FilipFl/logit/internal/prompter"
+	"github.com/spf13/cobra"
+)
+
+const defaultShownWorklogs = 5
+
+func NewShowCommand(cfg configuration.Config, prompter prompter.Prompter, gitHandler git.GitHandler, client jira.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show [alias | taskKey]",
+		Short: "Show task details, time tracking and recent worklogs",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			worklogCount, _ := cmd.Flags().GetInt("worklogs")
+			if worklogCount < 0 {
+				fmt.Println("Error validating flags:", errorNegativeWorklogCount)
+				return
+			}
+			task := ""
+			if len(args) > 0 {
+				task = taskFromArg(cfg, args[0])
+				if task == "" {
+					fmt.Println("Error assessing task to show:", errorNoJiraTask)
+					return
+				}
+			}
+			if task == "" {
+				force, _ := cmd.Flags().GetBool("force")
+				var err error
+				task, err = determineTask(cmd, cfg, prompter, gitHandler, client, force)
+				if err != nil {
+					fmt.Println("Error assessing task to show:", err)
+					return
+				}
+			}
+			details, err := client.GetIssueDetails(cmd.Context(), task, worklogCount)
+			if err != nil {
+				fmt.Println("Error fetching task:", explainJiraError(err, "read", task))
+				return
+			}
+			if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(details); err != nil {
+					fmt.Println("Error encoding task:", err)
+				}
+				return
+			}
+			printIssueDetails(os.Stdout, details)
+		},
+	}
+	cmd.Flags().StringP("task", "t", "", "Jira task ID or URL")
+	cmd.Flags().StringP("alias", "a", "", "Task by alias")
+	cmd.Flags().BoolP("force", "f", false, "Force approve when prompted")
+	cmd.Flags().IntP("worklogs", "n", defaultShownWorklogs, "How many most recent worklogs to show")
+	cmd.Flags().Bool("json", false, "Print task as JSON")
+	cmd.RegisterFlagCompletionFunc("alias", aliasCompletion(cfg))
+	return cmd
+}
+
+func printIssueDetails(out io.Writer, details jira.IssueDetails) {
+	fmt.Fprintf(out, "%s %s\n\n", details.Key, details.Summary)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Type:\t%s\n", details.Type)
+	fmt.Fprintf(w, "Status:\t%s\n", details.Status)
+	fmt.Fprintf(w, "Priority:\t%s\n", orDash(details.Priority))
+	fmt.Fprintf(w, "Assignee:\t%s\n", orDefault(details.Assignee, "Unassigned"))
+	fmt.Fprintf(w, "Reporter:\t%s\n", orDash(details.Reporter))
+	if len(details.Sprints) > 0 {
+		fmt.Fprintf(w, "Sprint:\t%s\n", strings.Join(details.Sprints, ", "))
+	}
+	if details.Parent != nil {
+		fmt.Fprintf(w, "Parent:\t%s %s\n", details.Parent.Key, details.Parent.Summary)
+	}
+	if details.Epic != "" {
+		fmt.Fprintf(w, "Epic:\t%s\n", details.Epic)
+	}
+	fmt.Fprintf(w, "Original estimate:\t%s\n", orDash(details.TimeTracking.OriginalEstimate))
+	fmt.Fprintf(w, "Remaining estimate:\t%s\n", orDash(details.TimeTracking.RemainingEstimate))
+	fmt.Fprintf(w, "Time spent:\t%s\n", orDash(details.TimeTracking.TimeSpent))
+	w.Flush()
+
+	if details.WorklogTotal == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\nLast %d of %d worklogs:\n", len(details.Worklogs), details.WorklogTotal)
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.Debug)
+	for _, log := range details.Worklogs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", log.Started.Format("2006-01-02 15:04"), log.Author, formatDuration(log.TimeSpent), truncateString(strings.ReplaceAll(log.Comment, "\n", " "), 40))
+	}
+	w.Flush()
+}
+
+func orDash(s string) string {
+	return orDefault(s, "-")
+}
+
+func orDefault(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
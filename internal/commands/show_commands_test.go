@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/FilipFl/logit/internal/jira"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintIssueDetails(t *testing.T) {
+	details := jira.IssueDetails{
+		Key:          "ABC-12",
+		Summary:      "Fix login",
+		Type:         "Sub-task",
+		Status:       "In Progress",
+		Reporter:     "John Roe",
+		Sprints:      []string{"Sprint 4"},
+		Parent:       &jira.IssueRef{Key: "ABC-1", Summary: "Login revamp"},
+		TimeTracking: jira.TimeTracking{OriginalEstimate: "1d", TimeSpent: "1h 30m"},
+		WorklogTotal: 3,
+		Worklogs: []jira.IssueWorklog{
+			{ID: "2", Author: "Jane Doe", Started: time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), TimeSpent: 90 * time.Minute, Comment: "Debugging\nsession"},
+		},
+	}
+	var out bytes.Buffer
+
+	printIssueDetails(&out, details)
+	assert.Equal(t, `ABC-12 Fix login
+
+Type:                Sub-task
+Status:              In Progress
+Priority:            -
+Assignee:            Unassigned
+Reporter:            John Roe
+Sprint:              Sprint 4
+Parent:              ABC-1 Login revamp
+Original estimate:   1d
+Remaining estimate:  -
+Time spent:          1h 30m
+
+Last 1 of 3 worklogs:
+2024-03-04 09:00  |Jane Doe  |1h 30m  |Debugging session
+`, out.String())
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/jira"
+	"github.com/FilipFl/logit/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+const myTasksJQL = "assignee = currentUser()"
+const defaultSprintsCount = 3
+
+func NewSprintTasksCommand(cfg configuration.Config, client jira.SprintClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tasks",
+		Short: "List my tasks in active sprint of configured board",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if cfg.GetBoardID() == 0 {
+				fmt.Println("Error reading sprint:", errorBoardNotConfigured)
+				return
+			}
+			sprints, err := client.GetSprints(cmd.Context(), cfg.GetBoardID(), jira.SprintActive)
+			if err != nil {
+				fmt.Println("Error reading sprint:", explainJiraError(err, "read sprints of board", ""))
+				return
+			}
+			if len(sprints) == 0 {
+				fmt.Println("Error reading sprint:", errorNoActiveSprint)
+				return
+			}
+			jql := myTasksJQL
+			if all, _ := cmd.Flags().GetBool("all"); all {
+				jql = ""
+			}
+			for _, sprint := range sprints {
+				issues, err := client.GetSprintIssues(cmd.Context(), sprint.ID, jql)
+				if err != nil {
+					fmt.Println("Error reading sprint tasks:", explainJiraError(err, "read sprint", ""))
+					return
+				}
+				printer.PrintGreen(describeSprint(sprint) + "\n")
+				printIssues(issues)
+			}
+		},
+	}
+	cmd.Flags().Bool("all", false, "List tasks of everyone")
+	return cmd
+}
+
+func NewSprintTimeCommand(cfg configuration.Config, client jira.SprintClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "time",
+		Short: "Show time I logged in active and recent sprints of configured board",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			count, _ := cmd.Flags().GetInt("sprints")
+			if count <= 0 {
+				fmt.Println("Error validating flags:", errorInvalidSprintsCount)
+				return
+			}
+			if cfg.GetBoardID() == 0 {
+				fmt.Println("Error reading sprints:", errorBoardNotConfigured)
+				return
+			}
+			sprints, err := client.GetRecentSprints(cmd.Context(), cfg.GetBoardID(), count, jira.SprintActive, jira.SprintClosed)
+			if err != nil {
+				fmt.Println("Error reading sprints:", explainJiraError(err, "read sprints of board", ""))
+				return
+			}
+			// sprints come oldest first, most recent are shown first
+			for i := len(sprints) - 1; i >= 0; i-- {
+				logged, err := client.GetSprintLoggedTime(cmd.Context(), sprints[i])
+				var partialErr *jira.PartialResultError
+				if err != nil && !errors.As(err, &partialErr) {
+					fmt.Println("Error fetching worklogs:", explainJiraError(err, "search worklogs", ""))
+					return
+				}
+				printSprintLoggedTime(logged)
+				if partialErr != nil {
+					fmt.Fprintln(os.Stderr, "Worklogs are incomplete:", partialErr)
+					fmt.Fprintln(os.Stderr, partialErr.Diagnostics())
+				}
+			}
+		},
+	}
+	cmd.Flags().IntP("sprints", "n", defaultSprintsCount, "How many most recent sprints to show")
+	return cmd
+}
+
+// activeSprintIssues lists issues matching jql in all active sprints of configured board.
+func activeSprintIssues(ctx context.Context, cfg configuration.Config, client jira.SprintClient, jql string) ([]jira.Issue, error) {
+	if cfg.GetBoardID() == 0 {
+		return nil, errorBoardNotConfigured
+	}
+	sprints, err := client.GetSprints(ctx, cfg.GetBoardID(), jira.SprintActive)
+	if err != nil {
+		return nil, err
+	}
+	issues := []jira.Issue{}
+	for _, sprint := range sprints {
+		sprintIssues, err := client.GetSprintIssues(ctx, sprint.ID, jql)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, sprintIssues...)
+	}
+	return issues, nil
+}
+
+func describeSprint(sprint jira.Sprint) string {
+	description := sprint.Name
+	if sprint.StartDate != nil && sprint.EndDate != nil {
+		description += fmt.Sprintf(" (%s - %s)", sprint.StartDate.Format("2006-01-02"), sprint.EndDate.Format("2006-01-02"))
+	}
+	if sprint.State == jira.SprintActive {
+		description += " [active]"
+	}
+	return description
+}
+
+func printSprintLoggedTime(logged jira.SprintLoggedTime) {
+	printer.PrintGreen(fmt.Sprintf("%s - %s\n", describeSprint(logged.Sprint), formatDuration(logged.Total)))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.Debug)
+	for _, task := range logged.Tasks {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", task.TaskKey, task.StringLoggedTime(), truncateString(task.Summary, 40))
+	}
+	w.Flush()
+}
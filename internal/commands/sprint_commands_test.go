@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/jira"
+	"github.com/FilipFl/logit/internal/prompter"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSprintClient struct {
+	sprints []jira.Sprint
+	issues  map[int][]jira.Issue
+	err     error
+}
+
+func (f *fakeSprintClient) GetSprints(ctx context.Context, boardID int, states ...string) ([]jira.Sprint, error) {
+	return f.sprints, f.err
+}
+
+func (f *fakeSprintClient) GetRecentSprints(ctx context.Context, boardID, count int, states ...string) ([]jira.Sprint, error) {
+	return f.sprints[max(len(f.sprints)-count, 0):], f.err
+}
+
+func (f *fakeSprintClient) GetSprintIssues(ctx context.Context, sprintID int, jql string) ([]jira.Issue, error) {
+	return f.issues[sprintID], f.err
+}
+
+func (f *fakeSprintClient) GetSprintLoggedTime(ctx context.Context, sprint jira.Sprint) (jira.SprintLoggedTime, error) {
+	return jira.SprintLoggedTime{Sprint: sprint}, f.err
+}
+
+func TestPromptForSprintTask(t *testing.T) {
+	activeSprint := &fakeSprintClient{
+		sprints: []jira.Sprint{{ID: 1, Name: "Sprint 1"}, {ID: 2, Name: "Parallel sprint"}},
+		issues: map[int][]jira.Issue{
+			1: {{Key: "ABC-1", Summary: "First"}},
+			2: {{Key: "XYZ-9", Summary: "Other"}},
+		},
+	}
+	tests := []struct {
+		name          string
+		boardID       int
+		sprints       jira.SprintClient
+		answer        string
+		expectedTask  string
+		expectedError error
+	}{
+		{
+			name:         "PickByNumber",
+			boardID:      42,
+			sprints:      activeSprint,
+			answer:       "2",
+			expectedTask: "XYZ-9",
+		},
+		{
+			name:         "TaskKeyInstead",
+			boardID:      42,
+			sprints:      activeSprint,
+			answer:       "DEF-3",
+			expectedTask: "DEF-3",
+		},
+		{
+			name:          "NumberOutOfRange",
+			boardID:       42,
+			sprints:       activeSprint,
+			answer:        "3",
+			expectedError: errorInvalidChoice,
+		},
+		{
+			name:         "NoBoard",
+			sprints:      activeSprint,
+			answer:       "DEF-3",
+			expectedTask: "DEF-3",
+		},
+		{
+			name:         "NoActiveSprint",
+			boardID:      42,
+			sprints:      &fakeSprintClient{},
+			answer:       "DEF-3",
+			expectedTask: "DEF-3",
+		},
+		{
+			name:         "NoSprintClient",
+			boardID:      42,
+			answer:       "DEF-3",
+			expectedTask: "DEF-3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConfig := configuration.NewMockConfig(&configuration.Cfg{BoardID: tt.boardID})
+			mockPrompter := prompter.NewMockPrompter()
+			mockPrompter.SetStringResponses([]string{tt.answer}, nil)
+
+			task, err := promptForSprintTask(context.Background(), mockConfig, mockPrompter, tt.sprints, "Current branch name does not contain task key.")
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTask, task)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/jira"
+	"github.com/FilipFl/logit/internal/printer"
+	"github.com/FilipFl/logit/internal/prompter"
+	"github.com/FilipFl/logit/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+func NewTimesheetStatusCommand(cfg configuration.Config, timer timer.Timer, client jira.TimesheetClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show approval status of Tempo timesheet",
+		Args:  nil,
+		Run: func(cmd *cobra.Command, args []string) {
+			if cfg.GetBackend() != configuration.BackendTempo {
+				fmt.Println(errorTimesheetRequiresTempo)
+				return
+			}
+			day, err := determinePeriod(cmd, timer)
+			if err != nil {
+				fmt.Println("Error validating flags:", err)
+				return
+			}
+			approval, err := client.GetTimesheetApproval(cmd.Context(), day)
+			if err != nil {
+				fmt.Println("Error fetching timesheet status:", explainJiraError(err, "read timesheet", ""))
+				return
+			}
+			printTimesheetApproval(approval)
+		},
+	}
+	cmd.Flags().StringP("period", "p", "", "Period as yyyy-mm or any day of it as yyyy-mm-dd (current period by default)")
+	return cmd
+}
+
+func NewTimesheetSubmitCommand(cfg configuration.Config, prompter prompter.Prompter, timer timer.Timer, client jira.TimesheetClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit",
+		Short: "Submit Tempo timesheet for approval",
+		Args:  nil,
+		Run: func(cmd *cobra.Command, args []string) {
+			if cfg.GetBackend() != configuration.BackendTempo {
+				fmt.Println(errorTimesheetRequiresTempo)
+				return
+			}
+			day, err := determinePeriod(cmd, timer)
+			if err != nil {
+				fmt.Println("Error validating flags:", err)
+				return
+			}
+			approval, err := client.GetTimesheetApproval(cmd.Context(), day)
+			if err != nil {
+				fmt.Println("Error fetching timesheet status:", explainJiraError(err, "read timesheet", ""))
+				return
+			}
+			printTimesheetApproval(approval)
+			if approval.IsSubmitted() {
+				fmt.Println("Timesheet is already submitted.")
+				return
+			}
+
+			from, fromErr := time.ParseInLocation(time.DateOnly, approval.Period.DateFrom, time.Local)
+			to, toErr := time.ParseInLocation(time.DateOnly, approval.Period.DateTo, time.Local)
+			if fromErr == nil && toErr == nil {
+				worked, err := client.GetWorkedDays(cmd.Context(), from, to)
+				if err != nil {
+					fmt.Println("Error checking logged days:", explainJiraError(err, "search worklogs", ""))
+					return
+				}
+				missing := missingWorkdays(worked, from, to, timer.Now())
+				force, _ := cmd.Flags().GetBool("force")
+				if len(missing) > 0 && !force {
+					dates := make([]string, 0, len(missing))
+					for _, d := range missing {
+						dates = append(dates, fmt.Sprintf("%s (%s)", d.Format(time.DateOnly), d.Weekday()))
+					}
+					printer.PrintYellow(fmt.Sprintf("No time logged on %d workdays: %s\n", len(missing), strings.Join(dates, ", ")))
+					proceed, err := prompter.PromptForApprove("Submit timesheet anyway?")
+					if err != nil {
+						fmt.Println("Error submitting timesheet:", err)
+						return
+					}
+					if !proceed {
+						fmt.Println("Error submitting timesheet:", errorOperationAborted)
+						return
+					}
+				}
+			}
+
+			reviewer, _ := cmd.Flags().GetString("reviewer")
+			comment, _ := cmd.Flags().GetString("comment")
+			approval, err = client.SubmitTimesheet(cmd.Context(), day, reviewer, comment)
+			if err != nil {
+				fmt.Println("Error submitting timesheet:", explainJiraError(err, "submit timesheet", ""))
+				return
+			}
+			fmt.Println("Timesheet submitted.")
+			printTimesheetApproval(approval)
+		},
+	}
+	cmd.Flags().StringP("period", "p", "", "Period as yyyy-mm or any day of it as yyyy-mm-dd (current period by default)")
+	cmd.Flags().StringP("reviewer", "r", "", "Key of user who should review the timesheet (Tempo picks one by default)")
+	cmd.Flags().StringP("comment", "c", "", "Comment for reviewer")
+	cmd.Flags().BoolP("force", "f", false, "Submit without warning about days without logged time")
+	return cmd
+}
+
+// determinePeriod returns a day of period passed with period flag, today by default.
+func determinePeriod(cmd *cobra.Command, timer timer.Timer) (time.Time, error) {
+	period, _ := cmd.Flags().GetString("period")
+	if period == "" {
+		return timer.Now(), nil
+	}
+	if day, err := time.ParseInLocation(time.DateOnly, period, time.Local); err == nil {
+		return day, nil
+	}
+	if month, err := time.ParseInLocation("2006-01", period, time.Local); err == nil {
+		return month, nil
+	}
+	return time.Time{}, errorInvalidPeriod
+}
+
+// missingWorkdays lists weekdays between from and to, but not after now,
+// with no time logged.
+func missingWorkdays(worked map[string]time.Duration, from, to, now time.Time) []time.Time {
+	missing := []time.Time{}
+	today := now.Format(time.DateOnly)
+	for day := from; !day.After(to) && day.Format(time.DateOnly) <= today; day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+		if worked[day.Format(time.DateOnly)] == 0 {
+			missing = append(missing, day)
+		}
+	}
+	return missing
+}
+
+func printTimesheetApproval(approval jira.TimesheetApproval) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "Period:\t%s - %s\n", approval.Period.DateFrom, approval.Period.DateTo)
+	fmt.Fprintf(w, "Status:\t%s\n", strings.ReplaceAll(approval.Status, "_", " "))
+	fmt.Fprintf(w, "Logged:\t%s\n", formatDuration(time.Duration(approval.WorkedSeconds)*time.Second))
+	if approval.RequiredSeconds > 0 {
+		fmt.Fprintf(w, "Required:\t%s\n", formatDuration(time.Duration(approval.RequiredSeconds)*time.Second))
+	}
+	if approval.Reviewer != nil {
+		fmt.Fprintf(w, "Reviewer:\t%s\n", approval.Reviewer.DisplayName)
+	}
+	w.Flush()
+}
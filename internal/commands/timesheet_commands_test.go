@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FilipFl/logit/internal/timer"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingWorkdays(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 10, 31, 0, 0, 0, 0, time.Local)
+	now := time.Date(2026, 10, 8, 15, 0, 0, 0, time.Local)
+	worked := map[string]time.Duration{
+		"2026-10-01": 8 * time.Hour,
+		"2026-10-02": 8 * time.Hour,
+		"2026-10-05": 8 * time.Hour,
+		"2026-10-07": 4 * time.Hour,
+	}
+
+	missing := missingWorkdays(worked, from, to, now)
+	dates := []string{}
+	for _, day := range missing {
+		dates = append(dates, day.Format(time.DateOnly))
+	}
+	// weekend 3-4 is skipped and days after today are not reported yet
+	assert.Equal(t, []string{"2026-10-06", "2026-10-08"}, dates)
+}
+
+func TestDeterminePeriod(t *testing.T) {
+	mockTimer := timer.NewMockTimer("2026-10-17T12:00:00.000Z")
+	tests := []struct {
+		period        string
+		expected      string
+		expectedError error
+	}{
+		{period: "", expected: "2026-10-17"},
+		{period: "2026-09", expected: "2026-09-01"},
+		{period: "2026-09-14", expected: "2026-09-14"},
+		{period: "09-2026", expectedError: errorInvalidPeriod},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("period", tt.period, "")
+			day, err := determinePeriod(cmd, mockTimer)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, day.Format(time.DateOnly))
+		})
+	}
+}
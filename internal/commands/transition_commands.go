@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/git"
+	"github.com/FilipFl/logit/internal/jira"
+	"github.com/FilipFl/logit/internal/prompter"
+	"github.com/spf13/cobra"
+)
+
+// Transition names differing by at most that many edits are treated as typos.
+const maxTransitionTypos = 2
+
+func NewTransitionCommand(cfg configuration.Config, prompter prompter.Prompter, gitHandler git.GitHandler, client jira.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transition [alias | taskKey] [status]",
+		Short: "Move task to another status",
+		Args:  cobra.MaximumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			task, query := "", ""
+			switch len(args) {
+			case 2:
+				task, query = taskFromArg(cfg, args[0]), args[1]
+				if task == "" {
+					fmt.Println("Error assessing task to transition:", errorNoJiraTask)
+					return
+				}
+			case 1:
+				// single argument is a task if it looks like one, otherwise it's a status
+				task = taskFromArg(cfg, args[0])
+				if task == "" {
+					query = args[0]
+				}
+			}
+			if task == "" {
+				force, _ := cmd.Flags().GetBool("force")
+				var err error
+				task, err = determineTask(cmd, cfg, prompter, gitHandler, client, force)
+				if err != nil {
+					fmt.Println("Error assessing task to transition:", err)
+					return
+				}
+			}
+			transition, err := transitionTask(cmd.Context(), client, prompter, task, query)
+			if err != nil {
+				fmt.Println("Error transitioning task:", explainJiraError(err, "transition", task))
+				return
+			}
+			fmt.Printf("Task %s moved to %s\n", task, transitionTarget(transition))
+		},
+	}
+	cmd.Flags().StringP("task", "t", "", "Jira task ID or URL")
+	cmd.Flags().StringP("alias", "a", "", "Task by alias")
+	cmd.Flags().BoolP("force", "f", false, "Force approve when prompted")
+	cmd.RegisterFlagCompletionFunc("alias", aliasCompletion(cfg))
+	return cmd
+}
+
+// taskFromArg resolves argument being either an alias or a task key/URL.
+func taskFromArg(cfg configuration.Config, arg string) string {
+	if task, err := cfg.GetTaskFromAlias(arg); err == nil && task != "" {
+		return task
+	}
+	task, _ := extractJiraTaskKey(arg)
+	return task
+}
+
+// transitionTask moves task using transition matching query, user is asked to
+// pick one when query is empty or ambiguous and to fill fields transition requires.
+func transitionTask(ctx context.Context, client jira.Client, prompter prompter.Prompter, task, query string) (jira.Transition, error) {
+	transitions, err := client.GetTransitions(ctx, task)
+	if err != nil {
+		return jira.Transition{}, err
+	}
+	transition, err := resolveTransition(prompter, transitions, query)
+	if err != nil {
+		return jira.Transition{}, err
+	}
+	fields, err := promptRequiredFields(prompter, fmt.Sprintf("Transition %s", transition.Name), transition.Fields, nil)
+	if err != nil {
+		return jira.Transition{}, err
+	}
+	return transition, client.TransitionIssue(ctx, task, transition.ID, fields)
+}
+
+func resolveTransition(prompter prompter.Prompter, transitions []jira.Transition, query string) (jira.Transition, error) {
+	if len(transitions) == 0 {
+		return jira.Transition{}, errorNoMatchingTransition
+	}
+	candidates := transitions
+	if query != "" {
+		candidates = matchTransitions(transitions, query)
+	}
+	if len(candidates) == 0 {
+		names := []string{}
+		for _, transition := range transitions {
+			names = append(names, transition.Name)
+		}
+		return jira.Transition{}, fmt.Errorf("%w %q, available: %s", errorNoMatchingTransition, query, strings.Join(names, ", "))
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	for i, transition := range candidates {
+		fmt.Printf("%d. %s -> %s\n", i+1, transition.Name, transition.To.Name)
+	}
+	n, err := promptForChoice(prompter, "", "Pick transition number:", len(candidates))
+	if err != nil {
+		return jira.Transition{}, err
+	}
+	return candidates[n], nil
+}
+
+// matchTransitions finds transitions whose name or target status matches
+// query. Exact matches win over prefixes, prefixes over substrings and
+// substrings over names with a typo or two.
+func matchTransitions(transitions []jira.Transition, query string) []jira.Transition {
+	q := normalizeName(query)
+	matchers := []func(name string) bool{
+		func(name string) bool { return name == q },
+		func(name string) bool { return strings.HasPrefix(name, q) },
+		func(name string) bool { return strings.Contains(name, q) },
+		func(name string) bool { return editDistance(name, q) <= maxTransitionTypos },
+	}
+	for _, matches := range matchers {
+		found := []jira.Transition{}
+		for _, transition := range transitions {
+			if matches(normalizeName(transition.Name)) || matches(normalizeName(transition.To.Name)) {
+				found = append(found, transition)
+			}
+		}
+		if len(found) > 0 {
+			return found
+		}
+	}
+	return nil
+}
+
+func normalizeName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func transitionTarget(transition jira.Transition) string {
+	if transition.To.Name != "" {
+		return transition.To.Name
+	}
+	return transition.Name
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/FilipFl/logit/internal/jira"
+	"github.com/FilipFl/logit/internal/prompter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchTransitions(t *testing.T) {
+	transitions := []jira.Transition{
+		{ID: "11", Name: "Start Progress", To: jira.JiraStatus{Name: "In Progress"}},
+		{ID: "21", Name: "Request review", To: jira.JiraStatus{Name: "In Review"}},
+		{ID: "31", Name: "Done", To: jira.JiraStatus{Name: "Done"}},
+		{ID: "41", Name: "Reopen", To: jira.JiraStatus{Name: "To Do"}},
+	}
+	tests := []struct {
+		query       string
+		expectedIDs []string
+	}{
+		{query: "done", expectedIDs: []string{"31"}},
+		{query: "In Review", expectedIDs: []string{"21"}},
+		{query: "in-review", expectedIDs: []string{"21"}},
+		{query: "in", expectedIDs: []string{"11", "21"}},
+		{query: "progress", expectedIDs: []string{"11"}},
+		{query: "In Reveiw", expectedIDs: []string{"21"}},
+		{query: "todo", expectedIDs: []string{"41"}},
+		{query: "closed", expectedIDs: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			ids := []string{}
+			for _, transition := range matchTransitions(transitions, tt.query) {
+				ids = append(ids, transition.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestResolveTransition(t *testing.T) {
+	transitions := []jira.Transition{
+		{ID: "11", Name: "Start Progress", To: jira.JiraStatus{Name: "In Progress"}},
+		{ID: "21", Name: "Request review", To: jira.JiraStatus{Name: "In Review"}},
+	}
+	mockPrompter := prompter.NewMockPrompter()
+	mockPrompter.SetStringResponses([]string{"2"}, nil)
+
+	transition, err := resolveTransition(mockPrompter, transitions, "in")
+	assert.NoError(t, err)
+	assert.Equal(t, "21", transition.ID)
+
+	_, err = resolveTransition(mockPrompter, transitions, "closed")
+	assert.ErrorIs(t, err, errorNoMatchingTransition)
+}
+
+func TestPromptTransitionFields(t *testing.T) {
+	transition := jira.Transition{
+		Name: "Done",
+		Fields: map[string]jira.FieldMeta{
+			"resolution":   {Name: "Resolution", Required: true, AllowedValues: []jira.AllowedValue{{ID: "1", Name: "Fixed"}, {ID: "2", Name: "Won't Do"}}},
+			"customfield1": {Name: "Release notes", Required: true},
+			"comment":      {Name: "Comment"},
+			"assignee":     {Name: "Assignee", Required: true, HasDefault: true},
+		},
+	}
+	mockPrompter := prompter.NewMockPrompter()
+	mockPrompter.SetStringResponses([]string{"Fixed login", "2"}, nil)
+
+	fields, err := promptRequiredFields(mockPrompter, "Transition Done", transition.Fields, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"customfield1": "Fixed login",
+		"resolution":   map[string]string{"id": "2"},
+	}, fields)
+}
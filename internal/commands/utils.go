@@ -1,18 +1,26 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/FilipFl/logit/internal/configuration"
 	"github.com/FilipFl/logit/internal/git"
+	"github.com/FilipFl/logit/internal/jira"
 	"github.com/FilipFl/logit/internal/prompter"
 	"github.com/FilipFl/logit/internal/timer"
 	"github.com/spf13/cobra"
 )
 
 const provideTaskMessage = "Provide task key or task URL:"
+const pickSprintTaskMessage = "Pick task number or provide task key or task URL:"
 
 func extractJiraTaskKey(arg string) (string, error) {
 	re := regexp.MustCompile(`([A-Z]+-\d+)`)
@@ -31,7 +39,91 @@ func promptForTask(prompter prompter.Prompter, msg string) (string, error) {
 	return extractJiraTaskKey(userPromptedMessage)
 }
 
-func determineTask(cmd *cobra.Command, config configuration.Config, prompter prompter.Prompter, gitHandler git.GitHandler, force bool) (string, error) {
+// promptForSprintTask lists user's tasks in active sprint of configured board
+// and lets them pick one by number or pass task key as promptForTask does.
+func promptForSprintTask(ctx context.Context, config configuration.Config, prompter prompter.Prompter, sprints jira.SprintClient, msg string) (string, error) {
+	if sprints == nil || config.GetBoardID() == 0 {
+		return promptForTask(prompter, msg)
+	}
+	issues, err := activeSprintIssues(ctx, config, sprints, myTasksJQL)
+	if err != nil || len(issues) == 0 {
+		return promptForTask(prompter, msg)
+	}
+	fmt.Println(msg, "Your tasks in active sprint:")
+	for i, issue := range issues {
+		fmt.Printf("%d. %s %s\n", i+1, issue.Key, truncateString(issue.Summary, 50))
+	}
+	answer, err := prompter.PromptForString("", pickSprintTaskMessage)
+	if err != nil {
+		return "", err
+	}
+	if n, err := strconv.Atoi(strings.TrimSpace(answer)); err == nil {
+		if n < 1 || n > len(issues) {
+			return "", errorInvalidChoice
+		}
+		return issues[n-1].Key, nil
+	}
+	return extractJiraTaskKey(answer)
+}
+
+// promptForChoice asks user for a number from 1 to count of options printed
+// before and returns index of chosen option.
+func promptForChoice(prompter prompter.Prompter, info, prompt string, count int) (int, error) {
+	choice, err := prompter.PromptForString(info, prompt)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(choice))
+	if err != nil || n < 1 || n > count {
+		return 0, errorInvalidChoice
+	}
+	return n - 1, nil
+}
+
+// promptRequiredFields asks for values of required fields without default
+// value, e.g. resolution of transition or component of created issue, skipping
+// fields which are already set. Context names the operation in prompts.
+func promptRequiredFields(prompter prompter.Prompter, operation string, metas map[string]jira.FieldMeta, set map[string]bool) (map[string]any, error) {
+	keys := []string{}
+	for key, field := range metas {
+		if field.Required && !field.HasDefault && !set[key] {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	slices.Sort(keys)
+	fields := map[string]any{}
+	for _, key := range keys {
+		field := metas[key]
+		if len(field.AllowedValues) == 0 {
+			value, err := prompter.PromptForString(fmt.Sprintf("%s requires %s.", operation, field.Name), fmt.Sprintf("Enter %s:", field.Name))
+			if err != nil {
+				return nil, err
+			}
+			fields[key] = value
+			continue
+		}
+		for i, value := range field.AllowedValues {
+			fmt.Printf("%d. %s\n", i+1, value.Label())
+		}
+		n, err := promptForChoice(prompter, fmt.Sprintf("%s requires %s.", operation, field.Name), fmt.Sprintf("Pick %s number:", field.Name), len(field.AllowedValues))
+		if err != nil {
+			return nil, err
+		}
+		var value any = map[string]string{"id": field.AllowedValues[n].ID}
+		if field.Schema.Type == "array" {
+			value = []any{value}
+		}
+		fields[key] = value
+	}
+	return fields, nil
+}
+
+// determineTask finds task in flags or current git branch. When branch holds
+// no task and sprints is not nil, user may pick one of their tasks in active sprint.
+func determineTask(cmd *cobra.Command, config configuration.Config, prompter prompter.Prompter, gitHandler git.GitHandler, sprints jira.SprintClient, force bool) (string, error) {
 	resultTask := ""
 	task, _ := cmd.Flags().GetString("task")
 	alias, _ := cmd.Flags().GetString("alias")
@@ -61,12 +153,12 @@ func determineTask(cmd *cobra.Command, config configuration.Config, prompter pro
 	}
 	gitBranch, err := gitHandler.GetGitBranch()
 	if err != nil {
-		return promptForTask(prompter, "Current directory is not a git repository or something failed during branch name extraction.")
+		return promptForSprintTask(cmd.Context(), config, prompter, sprints, "Current directory is not a git repository or something failed during branch name extraction.")
 	}
 
 	resultTask, err = extractJiraTaskKey(gitBranch)
 	if err != nil {
-		return promptForTask(prompter, "Current branch name does not contain task key.")
+		return promptForSprintTask(cmd.Context(), config, prompter, sprints, "Current branch name does not contain task key.")
 	}
 
 	proceed := config.GetTrustGitBranch() || force
@@ -84,6 +176,51 @@ func determineTask(cmd *cobra.Command, config configuration.Config, prompter pro
 	return "", errorOperationAborted
 }
 
+// aliasCompletion completes alias flag with aliases saved in config.
+func aliasCompletion(config configuration.Config) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		aliases := []string{}
+		for alias := range config.GetAliases() {
+			aliases = append(aliases, alias)
+		}
+		return aliases, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// determineVisibility returns visibility passed with flag or, when logging via
+// alias, default visibility of the alias. Explicit empty flag lifts alias default.
+func determineVisibility(cmd *cobra.Command, config configuration.Config) (*jira.Visibility, error) {
+	visibility, _ := cmd.Flags().GetString("visibility")
+	if !cmd.Flags().Changed("visibility") {
+		alias, _ := cmd.Flags().GetString("alias")
+		if alias != "" {
+			visibility = config.GetAliasVisibility(alias)
+		}
+	}
+	if visibility == "" {
+		return nil, nil
+	}
+	return jira.ParseVisibility(visibility)
+}
+
+// parseWorkAttributes parses repeated attribute flags in format name=value.
+func parseWorkAttributes(cmd *cobra.Command) (map[string]string, error) {
+	values, _ := cmd.Flags().GetStringArray("attribute")
+	if len(values) == 0 {
+		return nil, nil
+	}
+	attributes := map[string]string{}
+	for _, value := range values {
+		name, attributeValue, found := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("%w: %q", errorInvalidWorkAttribute, value)
+		}
+		attributes[name] = strings.TrimSpace(attributeValue)
+	}
+	return attributes, nil
+}
+
 func assertFlagsAreValid(cmd *cobra.Command, timer timer.Timer) error {
 	task, _ := cmd.Flags().GetString("task")
 	alias, _ := cmd.Flags().GetString("alias")
@@ -243,3 +380,37 @@ func worklogsFromHowManyDays(cmd *cobra.Command) int {
 	}
 	return 8
 }
+
+// explainJiraError translates Jira API errors into actionable messages.
+// action describes attempted operation, e.g. "log work on", and is followed by taskKey if any.
+func explainJiraError(err error, action, taskKey string) error {
+	var apiErr *jira.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	target := strings.TrimSpace(action + " " + taskKey)
+	switch apiErr.StatusCode {
+	case http.StatusUnauthorized:
+		switch apiErr.AuthMode {
+		case configuration.AuthBasic:
+			return errors.New("Jira rejected credentials - user name or password is wrong, update them with `logit config set-username` and `logit config set-token`")
+		case configuration.AuthOAuth1:
+			return errors.New("Jira rejected credentials - OAuth access was revoked or expired, authorize logit again with `logit config init`")
+		}
+		return errors.New("Jira rejected credentials - token expired or is invalid, update it with `logit config set-token`")
+	case http.StatusForbidden:
+		return fmt.Errorf("no permission to %s", target)
+	case http.StatusNotFound:
+		if taskKey != "" {
+			return fmt.Errorf("issue %s does not exist or You are not allowed to see it", taskKey)
+		}
+		return fmt.Errorf("Jira could not find resource needed to %s", target)
+	case http.StatusBadRequest:
+		messages := apiErr.Messages()
+		if len(messages) == 0 {
+			return fmt.Errorf("Jira rejected request to %s", target)
+		}
+		return fmt.Errorf("Jira rejected request to %s: %s", target, strings.Join(messages, "; "))
+	}
+	return err
+}
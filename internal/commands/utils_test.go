@@ -2,11 +2,14 @@ package commands
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/FilipFl/logit/internal/configuration"
 	"github.com/FilipFl/logit/internal/git"
+	"github.com/FilipFl/logit/internal/jira"
 	"github.com/FilipFl/logit/internal/prompter"
 	"github.com/FilipFl/logit/internal/timer"
 
@@ -164,7 +167,7 @@ func TestDetermineTask(t *testing.T) {
 			cmd.Flags().String("task", tt.taskFlag, "")
 			cmd.Flags().String("alias", tt.aliasFlag, "")
 
-			task, err := determineTask(cmd, cfgHandlerMock, prompterMock, gitHandlerMock, tt.forceFlag)
+			task, err := determineTask(cmd, cfgHandlerMock, prompterMock, gitHandlerMock, nil, tt.forceFlag)
 
 			if tt.expectedError != nil {
 				assert.Equal(t, tt.expectedError, err)
@@ -525,3 +528,123 @@ func TestAssertFlagsAreValid(t *testing.T) {
 		})
 	}
 }
+
+func TestExplainJiraError(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		taskKey         string
+		expectedMessage string
+	}{
+		{
+			name:            "Unauthorized",
+			err:             &jira.APIError{StatusCode: http.StatusUnauthorized},
+			taskKey:         "PROJ-1",
+			expectedMessage: "Jira rejected credentials - token expired or is invalid, update it with `logit config set-token`",
+		},
+		{
+			name:            "UnauthorizedBasic",
+			err:             &jira.APIError{StatusCode: http.StatusUnauthorized, AuthMode: configuration.AuthBasic},
+			taskKey:         "PROJ-1",
+			expectedMessage: "Jira rejected credentials - user name or password is wrong, update them with `logit config set-username` and `logit config set-token`",
+		},
+		{
+			name:            "UnauthorizedOAuth1",
+			err:             &jira.APIError{StatusCode: http.StatusUnauthorized, AuthMode: configuration.AuthOAuth1},
+			taskKey:         "PROJ-1",
+			expectedMessage: "Jira rejected credentials - OAuth access was revoked or expired, authorize logit again with `logit config init`",
+		},
+		{
+			name:            "Forbidden",
+			err:             fmt.Errorf("failed to log time: %w", &jira.APIError{StatusCode: http.StatusForbidden}),
+			taskKey:         "PROJ-1",
+			expectedMessage: "no permission to log work on PROJ-1",
+		},
+		{
+			name:            "NotFound",
+			err:             &jira.APIError{StatusCode: http.StatusNotFound},
+			taskKey:         "PROJ-1",
+			expectedMessage: "issue PROJ-1 does not exist or You are not allowed to see it",
+		},
+		{
+			name:            "BadRequest",
+			err:             &jira.APIError{StatusCode: http.StatusBadRequest, Errors: map[string]string{"timeLogged": "invalid"}},
+			taskKey:         "PROJ-1",
+			expectedMessage: "Jira rejected request to log work on PROJ-1: timeLogged: invalid",
+		},
+		{
+			name:            "OtherError",
+			err:             errors.New("connection refused"),
+			taskKey:         "PROJ-1",
+			expectedMessage: "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := explainJiraError(tt.err, "log work on", tt.taskKey)
+			assert.EqualError(t, err, tt.expectedMessage)
+		})
+	}
+}
+
+func TestDetermineVisibility(t *testing.T) {
+	tests := []struct {
+		name               string
+		visibilityFlag     *string
+		aliasFlag          string
+		expectedVisibility *jira.Visibility
+		expectError        bool
+	}{
+		{
+			name: "NoVisibility",
+		},
+		{
+			name:               "FromFlag",
+			visibilityFlag:     ptr("group:security"),
+			expectedVisibility: &jira.Visibility{Type: jira.VisibilityGroup, Value: "security"},
+		},
+		{
+			name:               "FromAlias",
+			aliasFlag:          "incident",
+			expectedVisibility: &jira.Visibility{Type: jira.VisibilityRole, Value: "Developers"},
+		},
+		{
+			name:           "FlagOverridesAlias",
+			aliasFlag:      "incident",
+			visibilityFlag: ptr(""),
+		},
+		{
+			name:           "InvalidFlag",
+			visibilityFlag: ptr("security"),
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConfig := configuration.NewMockConfig(&configuration.Cfg{
+				Aliases:           map[string]string{"incident": "SEC-1"},
+				AliasVisibilities: map[string]string{"incident": "role:Developers"},
+			})
+			cmd := &cobra.Command{}
+			cmd.Flags().String("alias", tt.aliasFlag, "")
+			cmd.Flags().String("visibility", "", "")
+			if tt.visibilityFlag != nil {
+				cmd.Flags().Set("visibility", *tt.visibilityFlag)
+			}
+
+			visibility, err := determineVisibility(cmd, mockConfig)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedVisibility, visibility)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
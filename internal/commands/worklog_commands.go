@@ -0,0 +1,322 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/jira"
+	"github.com/FilipFl/logit/internal/prompter"
+	"github.com/FilipFl/logit/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+func NewEditWorklogCommand(cfg configuration.Config, prompter prompter.Prompter, timer timer.Timer, client jira.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit [worklogID]",
+		Short: "Change duration, date or comment of logged work",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := assertEditWorklogFlagsAreValid(cmd, args, timer)
+			if err != nil {
+				fmt.Println("Error validating flags:", err)
+				return
+			}
+			entry, err := selectWorklog(cmd, args, cfg, prompter, timer, client, true)
+			if err != nil {
+				fmt.Println("Error finding worklog to edit:", explainJiraError(err, "find worklog", ""))
+				return
+			}
+			update, err := worklogUpdateFromFlags(cmd, entry, timer)
+			if err != nil {
+				fmt.Println("Error validating flags:", err)
+				return
+			}
+			err = client.UpdateWorklog(cmd.Context(), entry.TaskKey, entry.ID, update)
+			if err != nil {
+				fmt.Println("Error updating worklog:", explainJiraError(err, "edit worklog on", entry.TaskKey))
+				return
+			}
+			fmt.Printf("Successfully updated worklog %s of task %s\n", entry.ID, entry.TaskKey)
+		},
+	}
+	addWorklogSelectionFlags(cmd, cfg)
+	cmd.Flags().IntP("hours", "H", 0, "New hours spent")
+	cmd.Flags().IntP("minutes", "m", 0, "New minutes spent")
+	cmd.Flags().String("new-date", "", "Move worklog to date in format dd-mm, time of day is kept")
+	cmd.Flags().StringP("comment", "c", "", "New worklog comment, pass empty string to remove it")
+	return cmd
+}
+
+func NewDeleteWorklogCommand(cfg configuration.Config, prompter prompter.Prompter, timer timer.Timer, client jira.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete [worklogID]",
+		Short: "Delete logged work",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := assertWorklogSelectionFlagsAreValid(cmd, args, timer)
+			if err != nil {
+				fmt.Println("Error validating flags:", err)
+				return
+			}
+			adjustment := estimateAdjustmentFromFlags(cmd, "increase-by")
+			if err := adjustment.Validate(); err != nil {
+				fmt.Println("Error validating flags:", err)
+				return
+			}
+			entry, err := selectWorklog(cmd, args, cfg, prompter, timer, client, false)
+			if err != nil {
+				fmt.Println("Error finding worklog to delete:", explainJiraError(err, "find worklog", ""))
+				return
+			}
+			description := describeWorklog(entry)
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				fmt.Printf("Would delete %s, remaining estimate: %s\n", description, describeEstimateAdjustment(adjustment, "increase"))
+				return
+			}
+			if force, _ := cmd.Flags().GetBool("force"); !force {
+				proceed, err := prompter.PromptForApprove(fmt.Sprintf("About to delete %s.", description))
+				if err != nil {
+					fmt.Println("Error deleting worklog:", err)
+					return
+				}
+				if !proceed {
+					fmt.Println("Error deleting worklog:", errorOperationAborted)
+					return
+				}
+			}
+			err = client.DeleteWorklog(cmd.Context(), entry.TaskKey, entry.ID, adjustment)
+			if err != nil {
+				fmt.Println("Error deleting worklog:", explainJiraError(err, "delete worklog on", entry.TaskKey))
+				return
+			}
+			if last := cfg.GetLastWorklog(); last != nil && last.WorklogID == entry.ID {
+				cfg.SetLastWorklog(nil)
+			}
+			fmt.Printf("Successfully deleted %s\n", description)
+		},
+	}
+	addWorklogSelectionFlags(cmd, cfg)
+	addEstimateAdjustmentFlags(cmd, "increase-by", "Time to add back to remaining estimate in manual mode, e.g. 1h 30m")
+	cmd.Flags().BoolP("force", "f", false, "Delete without asking for confirmation")
+	cmd.Flags().Bool("dry-run", false, "Only show which worklog would be deleted")
+	return cmd
+}
+
+// addWorklogSelectionFlags adds flags used by selectWorklog to pick worklog to operate on.
+func addWorklogSelectionFlags(cmd *cobra.Command, cfg configuration.Config) {
+	cmd.Flags().BoolP("last", "l", false, "Use the most recent worklog logged from this machine")
+	cmd.Flags().StringP("task", "t", "", "Pick only from worklogs of Jira task ID or URL")
+	cmd.Flags().StringP("alias", "a", "", "Pick only from worklogs of task by alias")
+	cmd.Flags().BoolP("yesterday", "y", false, "Pick from worklogs logged yesterday")
+	cmd.Flags().StringP("date", "d", "", "Pick from worklogs logged on date in format dd-mm, present year is assumed")
+	cmd.RegisterFlagCompletionFunc("alias", aliasCompletion(cfg))
+}
+
+func assertEditWorklogFlagsAreValid(cmd *cobra.Command, args []string, timer timer.Timer) error {
+	if err := assertWorklogSelectionFlagsAreValid(cmd, args, timer); err != nil {
+		return err
+	}
+	hours, _ := cmd.Flags().GetInt("hours")
+	minutes, _ := cmd.Flags().GetInt("minutes")
+	if hours < 0 || minutes < 0 {
+		return errorWrongDuration
+	}
+	if cmd.Flags().Changed("hours") || cmd.Flags().Changed("minutes") {
+		if hours == 0 && minutes == 0 {
+			return errorWrongDuration
+		}
+	}
+	newDate, _ := cmd.Flags().GetString("new-date")
+	if newDate != "" {
+		if _, _, err := extractNewDayAndMonth(newDate, timer); err != nil {
+			return err
+		}
+	}
+	if !cmd.Flags().Changed("hours") && !cmd.Flags().Changed("minutes") && newDate == "" && !cmd.Flags().Changed("comment") {
+		return errorNothingToUpdate
+	}
+	return nil
+}
+
+func assertWorklogSelectionFlagsAreValid(cmd *cobra.Command, args []string, timer timer.Timer) error {
+	last, _ := cmd.Flags().GetBool("last")
+	task, _ := cmd.Flags().GetString("task")
+	alias, _ := cmd.Flags().GetString("alias")
+	yesterday, _ := cmd.Flags().GetBool("yesterday")
+	date, _ := cmd.Flags().GetString("date")
+
+	if task != "" && alias != "" {
+		return errorAliasAndTask
+	}
+	if yesterday && date != "" {
+		return errorYesterdayAndDate
+	}
+	picking := task != "" || alias != "" || yesterday || date != ""
+	if (last && len(args) > 0) || ((last || len(args) > 0) && picking) {
+		return errorConflictingWorklogSelection
+	}
+	if date != "" {
+		if _, _, err := extractNewDayAndMonth(date, timer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectWorklog finds worklog passed by ID, the last one logged from this
+// machine or lets user pick one of worklogs logged on a given day. With
+// confirmSingle user has to approve the worklog even if it's the only one found.
+func selectWorklog(cmd *cobra.Command, args []string, cfg configuration.Config, prompter prompter.Prompter, timer timer.Timer, client jira.Client, confirmSingle bool) (jira.WorklogEntry, error) {
+	if len(args) > 0 {
+		return client.GetWorklog(cmd.Context(), args[0])
+	}
+	if last, _ := cmd.Flags().GetBool("last"); last {
+		lastWorklog := cfg.GetLastWorklog()
+		if lastWorklog == nil || lastWorklog.WorklogID == "" {
+			return jira.WorklogEntry{}, errorNoLastWorklog
+		}
+		return client.GetWorklog(cmd.Context(), lastWorklog.WorklogID)
+	}
+
+	task := ""
+	if alias, _ := cmd.Flags().GetString("alias"); alias != "" {
+		var err error
+		task, err = cfg.GetTaskFromAlias(alias)
+		if err != nil {
+			return jira.WorklogEntry{}, err
+		}
+	}
+	if taskFlag, _ := cmd.Flags().GetString("task"); taskFlag != "" {
+		var err error
+		task, err = extractJiraTaskKey(taskFlag)
+		if err != nil {
+			return jira.WorklogEntry{}, err
+		}
+	}
+	day, err := determineStarted(cmd, timer)
+	if err != nil {
+		return jira.WorklogEntry{}, err
+	}
+	entries, err := client.GetWorklogsOn(cmd.Context(), day)
+	if err != nil {
+		return jira.WorklogEntry{}, err
+	}
+	if task != "" {
+		entries = filterWorklogsByTask(entries, task)
+	}
+	return pickWorklog(prompter, entries, day, confirmSingle)
+}
+
+func filterWorklogsByTask(entries []jira.WorklogEntry, task string) []jira.WorklogEntry {
+	filtered := []jira.WorklogEntry{}
+	for _, entry := range entries {
+		if entry.TaskKey == task {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// pickWorklog asks user to choose worklog by its number on printed list. The
+// only worklog found is returned right away unless confirmSingle is set.
+func pickWorklog(prompter prompter.Prompter, entries []jira.WorklogEntry, day time.Time, confirmSingle bool) (jira.WorklogEntry, error) {
+	if len(entries) == 0 {
+		return jira.WorklogEntry{}, fmt.Errorf("%w on %s", errorNoWorklogsToPick, day.Format(time.DateOnly))
+	}
+	if len(entries) == 1 && !confirmSingle {
+		return entries[0], nil
+	}
+	if len(entries) == 1 {
+		entry := entries[0]
+		proceed, err := prompter.PromptForApprove(fmt.Sprintf("Found worklog %s: %s %s on %s.", entry.ID, entry.TaskKey, formatDuration(entry.TimeSpent), entry.Started.Format("2006-01-02 15:04")))
+		if err != nil {
+			return jira.WorklogEntry{}, err
+		}
+		if !proceed {
+			return jira.WorklogEntry{}, errorOperationAborted
+		}
+		return entry, nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.Debug)
+	for i, entry := range entries {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t\n", i+1, entry.Started.Format("15:04"), entry.TaskKey, formatDuration(entry.TimeSpent), truncateString(entry.Comment, 30))
+	}
+	w.Flush()
+	n, err := promptForChoice(prompter, fmt.Sprintf("Found %d worklogs on %s.", len(entries), day.Format(time.DateOnly)), "Pick worklog number:", len(entries))
+	if err != nil {
+		return jira.WorklogEntry{}, err
+	}
+	return entries[n], nil
+}
+
+func worklogUpdateFromFlags(cmd *cobra.Command, entry jira.WorklogEntry, timer timer.Timer) (jira.WorklogUpdate, error) {
+	update := jira.WorklogUpdate{}
+	if cmd.Flags().Changed("hours") || cmd.Flags().Changed("minutes") {
+		hours, _ := cmd.Flags().GetInt("hours")
+		minutes, _ := cmd.Flags().GetInt("minutes")
+		duration := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+		update.TimeSpent = &duration
+	}
+	if newDate, _ := cmd.Flags().GetString("new-date"); newDate != "" {
+		date, err := parseDateFromString(newDate, timer)
+		if err != nil {
+			return update, err
+		}
+		original := entry.Started.In(date.Location())
+		started := time.Date(date.Year(), date.Month(), date.Day(), original.Hour(), original.Minute(), original.Second(), 0, date.Location())
+		update.Started = &started
+	}
+	if cmd.Flags().Changed("comment") {
+		comment, _ := cmd.Flags().GetString("comment")
+		update.Comment = &comment
+	}
+	return update, nil
+}
+
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func describeWorklog(entry jira.WorklogEntry) string {
+	return fmt.Sprintf("worklog %s of task %s: %s on %s", entry.ID, entry.TaskKey, formatDuration(entry.TimeSpent), entry.Started.Format("2006-01-02 15:04"))
+}
+
+// addEstimateAdjustmentFlags adds flags read by estimateAdjustmentFromFlags.
+// amountFlag names flag used in manual mode, it differs between logging and deleting work.
+func addEstimateAdjustmentFlags(cmd *cobra.Command, amountFlag, amountUsage string) {
+	cmd.Flags().String("adjust-estimate", "", "How to adjust remaining estimate: auto, leave, new or manual")
+	cmd.Flags().String("new-estimate", "", "New remaining estimate when adjusting to a new value, e.g. 2d 4h")
+	cmd.Flags().String(amountFlag, "", amountUsage)
+	cmd.RegisterFlagCompletionFunc("adjust-estimate", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{jira.AdjustEstimateAuto, jira.AdjustEstimateLeave, jira.AdjustEstimateNew, jira.AdjustEstimateManual}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+func estimateAdjustmentFromFlags(cmd *cobra.Command, amountFlag string) jira.EstimateAdjustment {
+	mode, _ := cmd.Flags().GetString("adjust-estimate")
+	newEstimate, _ := cmd.Flags().GetString("new-estimate")
+	amount, _ := cmd.Flags().GetString(amountFlag)
+	return jira.EstimateAdjustment{
+		Mode:        strings.ToLower(strings.TrimSpace(mode)),
+		NewEstimate: strings.TrimSpace(newEstimate),
+		Amount:      strings.TrimSpace(amount),
+	}
+}
+
+// describeEstimateAdjustment explains adjustment, verb tells what manual mode does with the amount.
+func describeEstimateAdjustment(adjustment jira.EstimateAdjustment, verb string) string {
+	switch adjustment.Mode {
+	case jira.AdjustEstimateLeave:
+		return "left unchanged"
+	case jira.AdjustEstimateNew:
+		return "set to " + adjustment.NewEstimate
+	case jira.AdjustEstimateManual:
+		return verb + " by " + adjustment.Amount
+	}
+	return "adjusted automatically"
+}
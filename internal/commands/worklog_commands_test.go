@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FilipFl/logit/internal/jira"
+	"github.com/FilipFl/logit/internal/prompter"
+	"github.com/FilipFl/logit/internal/timer"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickWorklog(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	entries := []jira.WorklogEntry{
+		{ID: "100", TaskKey: "PROJ-1", Started: day.Add(9 * time.Hour), TimeSpent: time.Hour},
+		{ID: "101", TaskKey: "PROJ-2", Started: day.Add(11 * time.Hour), TimeSpent: 30 * time.Minute},
+	}
+	tests := []struct {
+		name             string
+		entries          []jira.WorklogEntry
+		confirmSingle    bool
+		approveResponses []bool
+		stringResponses  []string
+		expectedID       string
+		expectedError    error
+	}{
+		{
+			name:          "NoWorklogs",
+			entries:       []jira.WorklogEntry{},
+			expectedError: errorNoWorklogsToPick,
+		},
+		{
+			name:             "SingleWorklogApproved",
+			entries:          entries[:1],
+			confirmSingle:    true,
+			approveResponses: []bool{true},
+			expectedID:       "100",
+		},
+		{
+			name:             "SingleWorklogRejected",
+			entries:          entries[:1],
+			confirmSingle:    true,
+			approveResponses: []bool{false},
+			expectedError:    errorOperationAborted,
+		},
+		{
+			name:       "SingleWorklogWithoutConfirmation",
+			entries:    entries[:1],
+			expectedID: "100",
+		},
+		{
+			name:            "PickedByNumber",
+			entries:         entries,
+			stringResponses: []string{" 2 "},
+			expectedID:      "101",
+		},
+		{
+			name:            "NumberOutOfRange",
+			entries:         entries,
+			stringResponses: []string{"3"},
+			expectedError:   errorInvalidChoice,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPrompter := prompter.NewMockPrompter()
+			mockPrompter.SetApproveResponses(tt.approveResponses, nil)
+			mockPrompter.SetStringResponses(tt.stringResponses, nil)
+
+			entry, err := pickWorklog(mockPrompter, tt.entries, day, tt.confirmSingle)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedID, entry.ID)
+		})
+	}
+}
+
+func TestWorklogUpdateFromFlags(t *testing.T) {
+	mockTimer := timer.NewMockTimer("2026-10-17T15:00:00.000Z")
+	entry := jira.WorklogEntry{ID: "100", Started: time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Int("hours", 0, "")
+	cmd.Flags().Int("minutes", 0, "")
+	cmd.Flags().String("new-date", "", "")
+	cmd.Flags().String("comment", "", "")
+	cmd.Flags().Set("minutes", "45")
+	cmd.Flags().Set("new-date", "14-10")
+
+	update, err := worklogUpdateFromFlags(cmd, entry, mockTimer)
+	assert.NoError(t, err)
+	assert.Equal(t, 45*time.Minute, *update.TimeSpent)
+	assert.Equal(t, time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC), *update.Started)
+	assert.Nil(t, update.Comment)
+}
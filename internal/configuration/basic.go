@@ -23,7 +23,7 @@ func NewBasicConfig() *BasicConfig {
 	basicConfig := &BasicConfig{cfg: config, cfgFilePath: fullConfigPath}
 	_, err = os.Stat(fullDirName)
 	if err != nil {
-		err = os.Mkdir(fullDirName, 0777)
+		err = os.Mkdir(fullDirName, 0700)
 		if err != nil {
 			panic(fmt.Sprintf("Error creating config directory: %s", err))
 		}
@@ -50,11 +50,16 @@ func NewBasicConfig() *BasicConfig {
 }
 
 func (h *BasicConfig) persistCfg() error {
-	file, err := os.Create(h.cfgFilePath)
+	// config holds token, password or OAuth secret, only the owner may read it
+	file, err := os.OpenFile(h.cfgFilePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
+	// mode isn't applied to config created by older versions
+	if err := file.Chmod(0600); err != nil {
+		return err
+	}
 
 	encoder := json.NewEncoder(file)
 	err = encoder.Encode(h.cfg)
@@ -99,22 +104,97 @@ func (h *BasicConfig) GetSnapshot() *time.Time {
 	return h.cfg.Snapshot
 }
 
+func (h *BasicConfig) GetJiraTimeout() time.Duration {
+	return parseJiraTimeout(h.cfg.JiraTimeout)
+}
+
+func (h *BasicConfig) GetJiraFlavour() string {
+	return normalizeJiraFlavour(h.cfg.JiraFlavour)
+}
+
+func (h *BasicConfig) GetBackend() string {
+	return normalizeBackend(h.cfg.Backend)
+}
+
+func (h *BasicConfig) GetParallelism() int {
+	return normalizeParallelism(h.cfg.Parallelism)
+}
+
+func (h *BasicConfig) GetJiraUser() *JiraUser {
+	return h.cfg.JiraUser
+}
+
+func (h *BasicConfig) GetLastWorklog() *LastWorklog {
+	return h.cfg.LastWorklog
+}
+
+func (h *BasicConfig) GetTasksJQL() string {
+	return normalizeTasksJQL(h.cfg.TasksJQL)
+}
+
+func (h *BasicConfig) GetBoardID() int {
+	return h.cfg.BoardID
+}
+
+func (h *BasicConfig) GetConnection() Connection {
+	if h.cfg.Connection == nil {
+		return Connection{}
+	}
+	return *h.cfg.Connection
+}
+
+func (h *BasicConfig) GetAuthMode() string {
+	return normalizeAuthMode(h.cfg.AuthMode)
+}
+
+func (h *BasicConfig) GetJiraUsername() string {
+	return h.cfg.JiraUsername
+}
+
+func (h *BasicConfig) GetOAuth() OAuth {
+	if h.cfg.OAuth == nil {
+		return OAuth{}
+	}
+	return *h.cfg.OAuth
+}
+
+func (h *BasicConfig) GetServerInfo() *ServerInfo {
+	return h.cfg.ServerInfo
+}
+
+func (h *BasicConfig) GetQueries() map[string]string {
+	return h.cfg.Queries
+}
+
+func (h *BasicConfig) GetQuery(name string) (string, error) {
+	if jql, exists := h.cfg.Queries[name]; exists {
+		return jql, nil
+	}
+	return "", ErrorQueryDontExists
+}
+
 func (h *BasicConfig) SetJiraEmail(email string) error {
 	h.cfg.JiraEmail = email
+	h.cfg.JiraUser = nil
 	return h.persistCfg()
 }
 
 func (h *BasicConfig) SetJiraOrigin(o string) error {
 	h.cfg.JiraOrigin = o
+	h.cfg.JiraUser = nil
+	h.cfg.ServerInfo = nil
+	h.cfg.LastWorklog = nil
 	return h.persistCfg()
 }
 func (h *BasicConfig) SetJiraToken(t string) error {
 	h.cfg.JiraToken = t
+	h.cfg.JiraUser = nil
 	return h.persistCfg()
 }
 
 func (h *BasicConfig) SetJiraTokenEnvName(name string) error {
 	h.cfg.JiraTokenEnvName = name
+	h.cfg.JiraUser = nil
 	return h.persistCfg()
 }
 
@@ -135,9 +215,27 @@ func (h *BasicConfig) GetTaskFromAlias(a string) (string, error) {
 	return "", ErrorAliasDontExists
 }
 
+func (h *BasicConfig) GetAliasVisibility(a string) string {
+	return h.cfg.AliasVisibilities[a]
+}
+
+// SetAliasVisibility sets default visibility of alias, empty visibility removes it.
+func (h *BasicConfig) SetAliasVisibility(a, visibility string) error {
+	if visibility == "" {
+		delete(h.cfg.AliasVisibilities, a)
+		return h.persistCfg()
+	}
+	if h.cfg.AliasVisibilities == nil {
+		h.cfg.AliasVisibilities = map[string]string{}
+	}
+	h.cfg.AliasVisibilities[a] = visibility
+	return h.persistCfg()
+}
+
 func (h *BasicConfig) RemoveAlias(a string) error {
 	if _, exists := h.cfg.Aliases[a]; exists {
 		delete(h.cfg.Aliases, a)
+		delete(h.cfg.AliasVisibilities, a)
 		return h.persistCfg()
 	}
 	return ErrorAliasDontExists
@@ -147,3 +245,98 @@ func (h *BasicConfig) SetSnapshot(s *time.Time) error {
 	h.cfg.Snapshot = s
 	return h.persistCfg()
 }
+
+func (h *BasicConfig) SetJiraTimeout(timeout time.Duration) error {
+	h.cfg.JiraTimeout = timeout.String()
+	return h.persistCfg()
+}
+
+func (h *BasicConfig) SetJiraFlavour(flavour string) error {
+	h.cfg.JiraFlavour = flavour
+	h.cfg.JiraUser = nil
+	h.cfg.ServerInfo = nil
+	return h.persistCfg()
+}
+
+func (h *BasicConfig) SetBackend(backend string) error {
+	h.cfg.Backend = backend
+	return h.persistCfg()
+}
+
+func (h *BasicConfig) SetParallelism(n int) error {
+	h.cfg.Parallelism = n
+	return h.persistCfg()
+}
+
+func (h *BasicConfig) SetJiraUser(user *JiraUser) error {
+	h.cfg.JiraUser = user
+	return h.persistCfg()
+}
+
+func (h *BasicConfig) SetLastWorklog(worklog *LastWorklog) error {
+	h.cfg.LastWorklog = worklog
+	return h.persistCfg()
+}
+
+// SetTasksJQL sets query used to list tasks, empty JQL restores the default.
+func (h *BasicConfig) SetTasksJQL(jql string) error {
+	h.cfg.TasksJQL = jql
+	return h.persistCfg()
+}
+
+func (h *BasicConfig) SetQuery(name, jql string) error {
+	if h.cfg.Queries == nil {
+		h.cfg.Queries = map[string]string{}
+	}
+	h.cfg.Queries[name] = jql
+	return h.persistCfg()
+}
+
+func (h *BasicConfig) RemoveQuery(name string) error {
+	if _, exists := h.cfg.Queries[name]; exists {
+		delete(h.cfg.Queries, name)
+		return h.persistCfg()
+	}
+	return ErrorQueryDontExists
+}
+
+func (h *BasicConfig) SetBoardID(id int) error {
+	h.cfg.BoardID = id
+	return h.persistCfg()
+}
+
+// SetConnection stores connection settings, zero settings are removed from config.
+func (h *BasicConfig) SetConnection(connection Connection) error {
+	h.cfg.Connection = nil
+	if connection != (Connection{}) {
+		h.cfg.Connection = &connection
+	}
+	return h.persistCfg()
+}
+
+func (h *BasicConfig) SetAuthMode(mode string) error {
+	h.cfg.AuthMode = mode
+	h.cfg.JiraUser = nil
+	return h.persistCfg()
+}
+
+func (h *BasicConfig) SetJiraUsername(username string) error {
+	h.cfg.JiraUsername = username
+	h.cfg.JiraUser = nil
+	return h.persistCfg()
+}
+
+// SetOAuth stores OAuth credentials, zero credentials are removed from config.
+func (h *BasicConfig) SetOAuth(oauth OAuth) error {
+	h.cfg.OAuth = nil
+	if oauth != (OAuth{}) {
+		h.cfg.OAuth = &oauth
+	}
+	h.cfg.JiraUser = nil
+	return h.persistCfg()
+}
+
+func (h *BasicConfig) SetServerInfo(info *ServerInfo) error {
+	h.cfg.ServerInfo = info
+	return h.persistCfg()
+}
@@ -2,11 +2,17 @@ package configuration
 
 import (
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/FilipFl/logit/internal/prompter"
 	"github.com/spf13/cobra"
 )
 
+const insecureSkipVerifyWarning = "Disabling certificate verification lets anyone on the network read Your token. Are You sure"
+
 func NewSetOriginCommand(config Config) *cobra.Command {
 	return &cobra.Command{
 		Use:   "set-origin [origin]",
@@ -58,7 +64,7 @@ func NewSetTokenEnvNameCommand(config Config) *cobra.Command {
 func NewSetEmailCommand(config Config) *cobra.Command {
 	return &cobra.Command{
 		Use:   "set-email [email]",
-		Short: "Set Your jira email",
+		Short: "Set Your jira email (used to authenticate on Jira Cloud)",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			err := config.SetJiraEmail(args[0])
@@ -71,10 +77,186 @@ func NewSetEmailCommand(config Config) *cobra.Command {
 	}
 }
 
-func NewInitCommand(config Config, prompter prompter.Prompter) *cobra.Command {
+func NewSetTimeoutCommand(config Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-timeout [duration]",
+		Short: "Set timeout of a single Jira request (e.g. 30s, 1m)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			timeout, err := time.ParseDuration(args[0])
+			if err != nil || timeout <= 0 {
+				fmt.Println("Invalid timeout, expected positive duration like 30s or 1m")
+				return
+			}
+			err = config.SetJiraTimeout(timeout)
+			if err != nil {
+				fmt.Println("Failed setting timeout:", err)
+				return
+			}
+			fmt.Println("Jira request timeout updated.")
+		},
+	}
+}
+
+func NewSetParallelismCommand(config Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-parallelism [n]",
+		Short: "Set how many Jira requests logit may run at once when fetching worklogs",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n <= 0 {
+				fmt.Println("Invalid parallelism, expected positive number")
+				return
+			}
+			err = config.SetParallelism(n)
+			if err != nil {
+				fmt.Println("Failed setting parallelism:", err)
+				return
+			}
+			fmt.Println("Parallelism updated.")
+		},
+	}
+}
+
+func NewSetFlavourCommand(config Config) *cobra.Command {
+	return &cobra.Command{
+		Use:       "set-flavour [server | cloud]",
+		Short:     "Set Jira flavour - server (also Data Center) or cloud",
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{FlavourServer, FlavourCloud},
+		Run: func(cmd *cobra.Command, args []string) {
+			err := config.SetJiraFlavour(args[0])
+			if err != nil {
+				fmt.Println("Failed setting Jira flavour:", err)
+				return
+			}
+			fmt.Println("Jira flavour updated.")
+		},
+	}
+}
+
+func NewSetBackendCommand(config Config) *cobra.Command {
+	return &cobra.Command{
+		Use:       "set-backend [jira | tempo]",
+		Short:     "Set where worklogs are stored - plain Jira or Tempo Timesheets (Server and Data Center only)",
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{BackendJira, BackendTempo},
+		Run: func(cmd *cobra.Command, args []string) {
+			err := config.SetBackend(args[0])
+			if err != nil {
+				fmt.Println("Failed setting worklog backend:", err)
+				return
+			}
+			fmt.Println("Worklog backend updated.")
+		},
+	}
+}
+
+func NewSetBoardCommand(config Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-board [id]",
+		Short: "Set ID of Jira Software board sprints are read from (it's in board URL as rapidView or boards/ID)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			id, err := strconv.Atoi(args[0])
+			if err != nil || id <= 0 {
+				fmt.Println("Invalid board ID, expected positive number")
+				return
+			}
+			err = config.SetBoardID(id)
+			if err != nil {
+				fmt.Println("Failed setting board:", err)
+				return
+			}
+			fmt.Println("Board updated.")
+		},
+	}
+}
+
+func NewSetTasksJQLCommand(config Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-tasks-jql [jql]",
+		Short: "Set JQL query listing Your tasks, empty string restores the default",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := config.SetTasksJQL(strings.TrimSpace(args[0]))
+			if err != nil {
+				fmt.Println("Failed setting tasks JQL:", err)
+				return
+			}
+			fmt.Println("Tasks JQL updated.")
+		},
+	}
+}
+
+func NewSetConnectionCommand(config Config, prompter prompter.Prompter, validate func(Connection) error) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-connection",
+		Short: "Set proxy, trusted CA bundle and client certificate used to connect to Jira",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			current := config.GetConnection()
+			connection := connectionFromFlags(cmd, current)
+			if connection.InsecureSkipVerify && !current.InsecureSkipVerify {
+				approve, err := prompter.PromptForApprove(insecureSkipVerifyWarning)
+				if err != nil {
+					fmt.Println("Error setting connection:", err)
+					return
+				}
+				if !approve {
+					return
+				}
+			}
+			if err := validate(connection); err != nil {
+				fmt.Println("Error setting connection:", err)
+				return
+			}
+			if err := config.SetConnection(connection); err != nil {
+				fmt.Println("Failed setting connection:", err)
+				return
+			}
+			fmt.Println("Connection settings updated.")
+		},
+	}
+	cmd.Flags().String("proxy", "", "Proxy URL, http, https or socks5 (empty string restores proxy from environment)")
+	cmd.Flags().String("ca-bundle", "", "Path to PEM file with additionally trusted CA certificates")
+	cmd.Flags().String("client-cert", "", "Path to PEM client certificate")
+	cmd.Flags().String("client-key", "", "Path to PEM key of client certificate")
+	cmd.Flags().Bool("insecure-skip-verify", false, "Skip verification of server certificate, not allowed for Jira Cloud")
+	cmd.Flags().Bool("allow-http", false, "Allow sending token over plain http to non-local origin")
+	return cmd
+}
+
+// connectionFromFlags overrides current settings with flags user passed.
+func connectionFromFlags(cmd *cobra.Command, current Connection) Connection {
+	connection := current
+	flags := cmd.Flags()
+	if flags.Changed("proxy") {
+		connection.Proxy, _ = flags.GetString("proxy")
+	}
+	if flags.Changed("ca-bundle") {
+		connection.CABundle, _ = flags.GetString("ca-bundle")
+	}
+	if flags.Changed("client-cert") {
+		connection.ClientCert, _ = flags.GetString("client-cert")
+	}
+	if flags.Changed("client-key") {
+		connection.ClientKey, _ = flags.GetString("client-key")
+	}
+	if flags.Changed("insecure-skip-verify") {
+		connection.InsecureSkipVerify, _ = flags.GetBool("insecure-skip-verify")
+	}
+	if flags.Changed("allow-http") {
+		connection.AllowInsecureHTTP, _ = flags.GetBool("allow-http")
+	}
+	return connection
+}
+
+func NewInitCommand(config Config, prompter prompter.Prompter, newHTTPClient func() (*http.Client, error)) *cobra.Command {
 	return &cobra.Command{
 		Use:   "init",
-		Short: "Initialize config. Logit will prompt for Jira origin, and Your Jira PAT Access",
+		Short: "Initialize config. Logit will prompt for Jira origin, flavour and credentials - PAT, password or OAuth (API token on Jira Cloud)",
 		Args:  nil,
 		Run: func(cmd *cobra.Command, args []string) {
 			origin, err := prompter.PromptForString("", "Please enter Jira origin (schema + host): ")
@@ -87,22 +269,76 @@ func NewInitCommand(config Config, prompter prompter.Prompter) *cobra.Command {
 				fmt.Println("Failed setting origin:", err)
 				return
 			}
-			email, err := prompter.PromptForString("", "Please enter Jira email: ")
+			cloud, err := prompter.PromptForApprove("Is Your Jira hosted in Atlassian Cloud?")
 			if err != nil {
 				fmt.Println("operation aborted ", err)
 				return
 			}
-			err = config.SetJiraEmail(email)
+			flavour := FlavourServer
+			tokenName := "Personal Access Token"
+			if cloud {
+				flavour = FlavourCloud
+				tokenName = "API token"
+			}
+			err = config.SetJiraFlavour(flavour)
 			if err != nil {
-				fmt.Println("Failed setting email:", err)
+				fmt.Println("Failed setting Jira flavour:", err)
 				return
 			}
-			directToken, err := prompter.PromptForApprove("Do You want to provide Personal Access Token directly to be stored in config file?")
+			if cloud {
+				email, err := prompter.PromptForString("", "Please enter Jira email: ")
+				if err != nil {
+					fmt.Println("operation aborted ", err)
+					return
+				}
+				err = config.SetJiraEmail(email)
+				if err != nil {
+					fmt.Println("Failed setting email:", err)
+					return
+				}
+			}
+			mode := AuthToken
+			if !cloud {
+				mode, err = promptForAuthMode(prompter)
+				if err != nil {
+					fmt.Println("operation aborted ", err)
+					return
+				}
+			}
+			err = config.SetAuthMode(mode)
+			if err != nil {
+				fmt.Println("Failed setting authentication:", err)
+				return
+			}
+			switch mode {
+			case AuthOAuth1:
+				err = authorizeOAuth(cmd.Context(), config, prompter, newHTTPClient)
+				if err != nil {
+					fmt.Println("Failed authorizing logit:", err)
+					return
+				}
+				fmt.Println("Jira config saved")
+				return
+			case AuthBasic:
+				username, err := prompter.PromptForString("", "Please enter Jira user name: ")
+				if err != nil {
+					fmt.Println("operation aborted ", err)
+					return
+				}
+				err = config.SetJiraUsername(username)
+				if err != nil {
+					fmt.Println("Failed setting user name:", err)
+					return
+				}
+				tokenName = "password"
+			}
+			directToken, err := prompter.PromptForApprove(fmt.Sprintf("Do You want to provide %s directly to be stored in config file?", tokenName))
 			if err != nil {
 				fmt.Println("operation aborted ", err)
+				return
 			}
 			if directToken {
-				token, err := prompter.PromptForString("", "Please enter Your Personal Access Token: ")
+				token, err := prompter.PromptForString("", fmt.Sprintf("Please enter Your %s: ", tokenName))
 				if err != nil {
 					fmt.Println("operation aborted ", err)
 					return
@@ -129,6 +365,56 @@ func NewInitCommand(config Config, prompter prompter.Prompter) *cobra.Command {
 	}
 }
 
+func promptForAuthMode(prompter prompter.Prompter) (string, error) {
+	mode, err := prompter.PromptForString(
+		"Authentication: token (Personal Access Token), basic (user name and password) or oauth1 (OAuth 1.0a application link)",
+		"Please enter authentication: ",
+	)
+	if err != nil {
+		return "", err
+	}
+	if mode != AuthToken && mode != AuthBasic && mode != AuthOAuth1 {
+		return "", ErrorInvalidAuthMode
+	}
+	return mode, nil
+}
+
+func NewSetAuthModeCommand(config Config) *cobra.Command {
+	return &cobra.Command{
+		Use:       "set-auth [token | basic | oauth1]",
+		Short:     "Set authentication - token, basic (user name and password) or oauth1 (Server and Data Center only)",
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{AuthToken, AuthBasic, AuthOAuth1},
+		Run: func(cmd *cobra.Command, args []string) {
+			err := config.SetAuthMode(args[0])
+			if err != nil {
+				fmt.Println("Failed setting authentication:", err)
+				return
+			}
+			fmt.Println("Authentication updated.")
+			if args[0] == AuthOAuth1 && config.GetOAuth().AccessToken == "" {
+				fmt.Println("Run `logit config init` to authorize logit with OAuth.")
+			}
+		},
+	}
+}
+
+func NewSetUsernameCommand(config Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-username [username]",
+		Short: "Set Jira user name used with basic auth",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := config.SetJiraUsername(args[0])
+			if err != nil {
+				fmt.Println("Failed setting user name:", err)
+				return
+			}
+			fmt.Println("Jira user name updated.")
+		},
+	}
+}
+
 func NewSwitchTrustGitBranchCommand(config Config) *cobra.Command {
 	return &cobra.Command{
 		Use:   "trustGitBranch",
@@ -148,6 +434,14 @@ func NewSwitchTrustGitBranchCommand(config Config) *cobra.Command {
 	}
 }
 
+// secretState tells whether secret is configured without revealing it.
+func secretState(secret string) string {
+	if secret == "" {
+		return "not set"
+	}
+	return "set"
+}
+
 func NewShowConfigCommand(config Config) *cobra.Command {
 	return &cobra.Command{
 		Use:   "show",
@@ -155,14 +449,78 @@ func NewShowConfigCommand(config Config) *cobra.Command {
 		Args:  nil,
 		Run: func(cmd *cobra.Command, args []string) {
 			fmt.Println("Jira Origin:", config.GetJiraOrigin())
+			fmt.Println("Jira Flavour:", config.GetJiraFlavour())
+			printServerInfo(config)
+			fmt.Println("Worklog backend:", config.GetBackend())
 			fmt.Println("Jira Email:", config.GetJiraEmail())
-			fmt.Println("Jira Token:", config.GetJiraToken())
+			fmt.Println("Authentication:", config.GetAuthMode())
+			tokenName := "Jira Token"
+			switch config.GetAuthMode() {
+			case AuthBasic:
+				fmt.Println("Jira User name:", config.GetJiraUsername())
+				tokenName = "Jira Password"
+			case AuthOAuth1:
+				fmt.Println("OAuth consumer key:", config.GetOAuth().ConsumerKey)
+				fmt.Println("OAuth private key:", config.GetOAuth().PrivateKeyPath)
+				fmt.Println("OAuth access token:", secretState(config.GetOAuth().AccessToken))
+			}
+			fmt.Println(tokenName+":", secretState(config.GetJiraToken()))
 			fmt.Println("Jira Token Environmental variable name:", config.GetJiraTokenEnvName())
 			fmt.Println("TrustGitBranch:", config.GetTrustGitBranch())
+			fmt.Println("Jira request timeout:", config.GetJiraTimeout())
+			fmt.Println("Parallelism:", config.GetParallelism())
+			fmt.Println("Tasks JQL:", config.GetTasksJQL())
+			if boardID := config.GetBoardID(); boardID != 0 {
+				fmt.Println("Board ID:", boardID)
+			}
+			if connection := config.GetConnection(); connection != (Connection{}) {
+				fmt.Println("Connection:")
+				printConnectionSetting("Proxy", connection.Proxy)
+				printConnectionSetting("CA bundle", connection.CABundle)
+				printConnectionSetting("Client certificate", connection.ClientCert)
+				printConnectionSetting("Client key", connection.ClientKey)
+				if connection.InsecureSkipVerify {
+					fmt.Println("   Certificate verification: DISABLED")
+				}
+				if connection.AllowInsecureHTTP {
+					fmt.Println("   Plain http allowed: true")
+				}
+			}
+			if user := config.GetJiraUser(); user != nil {
+				fmt.Println("Jira User:", user.DisplayName)
+			}
 			fmt.Println("Aliases:")
 			for key, value := range config.GetAliases() {
+				if visibility := config.GetAliasVisibility(key); visibility != "" {
+					fmt.Printf("   %s: %s (visible to %s)\n", key, value, visibility)
+					continue
+				}
 				fmt.Printf("   %s: %s\n", key, value)
 			}
+			if queries := config.GetQueries(); len(queries) > 0 {
+				fmt.Println("Queries:")
+				for name, jql := range queries {
+					fmt.Printf("   %s: %s\n", name, jql)
+				}
+			}
 		},
 	}
 }
+
+func printConnectionSetting(name, value string) {
+	if value != "" {
+		fmt.Printf("   %s: %s\n", name, value)
+	}
+}
+
+func printServerInfo(config Config) {
+	info := config.GetServerInfo()
+	if info == nil {
+		fmt.Println("Detected Jira: not yet, it's detected on first request to Jira")
+		return
+	}
+	fmt.Printf("Detected Jira: %s %s (checked %s)\n", info.DeploymentType, info.Version, info.CheckedAt.Format("2006-01-02"))
+	if (info.DeploymentType == "Cloud") != (config.GetJiraFlavour() == FlavourCloud) {
+		fmt.Println("   Detected deployment doesn't match flavour, fix it with `logit config set-flavour`")
+	}
+}
@@ -0,0 +1,48 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionFromFlags(t *testing.T) {
+	current := Connection{Proxy: "http://proxy.example:3128", CABundle: "/etc/ca.pem"}
+	tests := []struct {
+		name     string
+		args     []string
+		expected Connection
+	}{
+		{
+			name:     "NoFlags",
+			expected: current,
+		},
+		{
+			name:     "ClientCertificate",
+			args:     []string{"--client-cert", "client.crt", "--client-key", "client.key"},
+			expected: Connection{Proxy: "http://proxy.example:3128", CABundle: "/etc/ca.pem", ClientCert: "client.crt", ClientKey: "client.key"},
+		},
+		{
+			name:     "ClearProxy",
+			args:     []string{"--proxy", ""},
+			expected: Connection{CABundle: "/etc/ca.pem"},
+		},
+		{
+			name:     "InsecureOptions",
+			args:     []string{"--insecure-skip-verify", "--allow-http"},
+			expected: Connection{Proxy: "http://proxy.example:3128", CABundle: "/etc/ca.pem", InsecureSkipVerify: true, AllowInsecureHTTP: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewSetConnectionCommand(nil, nil, nil)
+			assert.NoError(t, cmd.ParseFlags(tt.args))
+			assert.Equal(t, tt.expected, connectionFromFlags(cmd, current))
+		})
+	}
+}
+
+func TestSecretState(t *testing.T) {
+	assert.Equal(t, "not set", secretState(""))
+	assert.Equal(t, "set", secretState("password123"))
+}
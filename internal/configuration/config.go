@@ -2,6 +2,35 @@ package configuration
 
 import "time"
 
+const defaultJiraTimeout = 30 * time.Second
+const defaultWorklogsParallelism = 8
+
+// DefaultTasksJQL lists open tasks of current user. Status category is used
+// instead of status names, as done statuses are named differently across projects.
+const DefaultTasksJQL = "assignee = currentUser() AND statusCategory != Done"
+
+// Jira flavours supported by logit. Server covers both Jira Server and Data Center.
+const (
+	FlavourServer = "server"
+	FlavourCloud  = "cloud"
+)
+
+// Worklog backends. Tempo stores worklogs through Tempo Timesheets so that
+// work attributes required by Tempo are filled in.
+const (
+	BackendJira  = "jira"
+	BackendTempo = "tempo"
+)
+
+// Authentication modes. Token authenticates with Personal Access Token on
+// Server and with email and API token on Cloud. Basic and OAuth1 are meant
+// for Server and Data Center instances with tokens disabled.
+const (
+	AuthToken  = "token"
+	AuthBasic  = "basic"
+	AuthOAuth1 = "oauth1"
+)
+
 type Cfg struct {
 	JiraOrigin       string            `json:"jira_origin"`
 	JiraToken        string            `json:"jira_token"`
@@ -10,6 +39,77 @@ type Cfg struct {
 	Aliases          map[string]string `json:"aliases"`
 	Snapshot         *time.Time        `json:"snapshot"`
 	TrustGitBranch   bool              `json:"trustGitBranch"`
+	JiraTimeout      string            `json:"jira_timeout,omitempty"`
+	JiraFlavour      string            `json:"jira_flavour,omitempty"`
+	Parallelism      int               `json:"parallelism,omitempty"`
+	JiraUser         *JiraUser         `json:"jira_user,omitempty"`
+	LastWorklog      *LastWorklog      `json:"last_worklog,omitempty"`
+	Backend          string            `json:"backend,omitempty"`
+	// AliasVisibilities maps alias to default visibility of worklogs logged with it, e.g. "role:Developers".
+	AliasVisibilities map[string]string `json:"alias_visibilities,omitempty"`
+	// TasksJQL is query used by `logit tasks`, DefaultTasksJQL when empty.
+	TasksJQL string `json:"tasks_jql,omitempty"`
+	// Queries maps name of saved query to its JQL.
+	Queries map[string]string `json:"queries,omitempty"`
+	// BoardID is Jira Software board sprints are read from, 0 when not set.
+	BoardID    int         `json:"board_id,omitempty"`
+	Connection *Connection `json:"connection,omitempty"`
+	// AuthMode is one of auth modes, AuthToken when empty.
+	AuthMode string `json:"auth_mode,omitempty"`
+	// JiraUsername is user name used with basic auth, password is kept like token.
+	JiraUsername string `json:"jira_username,omitempty"`
+	OAuth        *OAuth `json:"oauth,omitempty"`
+	// ServerInfo caches what Jira reported about itself, reset when origin or flavour changes.
+	ServerInfo *ServerInfo `json:"server_info,omitempty"`
+}
+
+// ServerInfo describes Jira instance as reported by its serverInfo endpoint.
+type ServerInfo struct {
+	Version        string `json:"version"`
+	VersionNumbers []int  `json:"versionNumbers"`
+	// DeploymentType is "Cloud", "Server" or "DataCenter".
+	DeploymentType string    `json:"deploymentType"`
+	CheckedAt      time.Time `json:"checkedAt"`
+}
+
+// OAuth holds OAuth 1.0a credentials of Jira application link.
+type OAuth struct {
+	ConsumerKey string `json:"consumer_key"`
+	// PrivateKeyPath is path to PEM RSA key whose public part is set in application link.
+	PrivateKeyPath string `json:"private_key_path"`
+	AccessToken    string `json:"access_token"`
+	TokenSecret    string `json:"token_secret,omitempty"`
+}
+
+// Connection holds network settings of connection to configured Jira instance.
+type Connection struct {
+	// Proxy is URL of proxy requests go through, proxy from environment is used when empty.
+	Proxy string `json:"proxy,omitempty"`
+	// CABundle is path to PEM file with certificates trusted besides system ones.
+	CABundle string `json:"ca_bundle,omitempty"`
+	// ClientCert and ClientKey are paths to PEM files used to authenticate with client certificate.
+	ClientCert string `json:"client_cert,omitempty"`
+	ClientKey  string `json:"client_key,omitempty"`
+	// InsecureSkipVerify disables verification of Jira certificate, for test instances only.
+	InsecureSkipVerify bool `json:"insecure_skip_verify,omitempty"`
+	// AllowInsecureHTTP allows sending token over plain http to hosts other than localhost.
+	AllowInsecureHTTP bool `json:"allow_insecure_http,omitempty"`
+}
+
+// JiraUser identifies authenticated Jira user. Server instances identify users
+// by key and name, Cloud by accountId.
+type JiraUser struct {
+	Key          string `json:"key,omitempty"`
+	Name         string `json:"name,omitempty"`
+	AccountID    string `json:"accountId,omitempty"`
+	DisplayName  string `json:"displayName,omitempty"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+}
+
+// LastWorklog points to the most recent worklog logged from this machine.
+type LastWorklog struct {
+	TaskKey   string `json:"task_key"`
+	WorklogID string `json:"worklog_id"`
 }
 
 type Config interface {
@@ -21,16 +121,92 @@ type Config interface {
 	GetAliases() map[string]string
 	GetTrustGitBranch() bool
 	GetSnapshot() *time.Time
+	GetJiraTimeout() time.Duration
+	GetJiraFlavour() string
+	GetBackend() string
+	GetParallelism() int
+	GetJiraUser() *JiraUser
+	GetLastWorklog() *LastWorklog
+	GetTasksJQL() string
+	GetBoardID() int
+	GetConnection() Connection
+	GetAuthMode() string
+	GetJiraUsername() string
+	GetOAuth() OAuth
+	GetServerInfo() *ServerInfo
+	GetQueries() map[string]string
+	GetQuery(name string) (string, error)
 	SetJiraOrigin(o string) error
 	SetJiraEmail(email string) error
 	SetJiraTokenEnvName(name string) error
 	SetJiraToken(t string) error
 	AddAlias(a, t string) error
 	GetTaskFromAlias(a string) (string, error)
+	GetAliasVisibility(a string) string
+	SetAliasVisibility(a, visibility string) error
 	RemoveAlias(a string) error
 	SwapTrustGitBranch() error
 	SetSnapshot(s *time.Time) error
+	SetJiraTimeout(timeout time.Duration) error
+	SetJiraFlavour(flavour string) error
+	SetBackend(backend string) error
+	SetParallelism(n int) error
+	SetJiraUser(user *JiraUser) error
+	SetLastWorklog(worklog *LastWorklog) error
+	SetTasksJQL(jql string) error
+	SetBoardID(id int) error
+	SetConnection(connection Connection) error
+	SetAuthMode(mode string) error
+	SetJiraUsername(username string) error
+	SetOAuth(oauth OAuth) error
+	SetServerInfo(info *ServerInfo) error
+	SetQuery(name, jql string) error
+	RemoveQuery(name string) error
 }
 
 const configDirectoryName = ".logit"
 const configFileName = "config.json"
+
+func parseJiraTimeout(s string) time.Duration {
+	timeout, err := time.ParseDuration(s)
+	if err != nil || timeout <= 0 {
+		return defaultJiraTimeout
+	}
+	return timeout
+}
+
+func normalizeJiraFlavour(flavour string) string {
+	if flavour == FlavourCloud {
+		return FlavourCloud
+	}
+	return FlavourServer
+}
+
+func normalizeAuthMode(mode string) string {
+	switch mode {
+	case AuthBasic, AuthOAuth1:
+		return mode
+	}
+	return AuthToken
+}
+
+func normalizeBackend(backend string) string {
+	if backend == BackendTempo {
+		return BackendTempo
+	}
+	return BackendJira
+}
+
+func normalizeTasksJQL(jql string) string {
+	if jql == "" {
+		return DefaultTasksJQL
+	}
+	return jql
+}
+
+func normalizeParallelism(n int) int {
+	if n <= 0 {
+		return defaultWorklogsParallelism
+	}
+	return n
+}
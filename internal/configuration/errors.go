@@ -4,3 +4,5 @@ import "errors"
 
 var ErrorAliasExists = errors.New("alias already exists")
 var ErrorAliasDontExists = errors.New("alias doesn't exists")
+var ErrorQueryDontExists = errors.New("query doesn't exists")
+var ErrorInvalidAuthMode = errors.New("authentication must be one of token, basic or oauth1")
@@ -66,6 +66,30 @@ func (h *MockConfig) GetSnapshot() *time.Time {
 	return h.config.Snapshot
 }
 
+func (h *MockConfig) GetJiraTimeout() time.Duration {
+	return parseJiraTimeout(h.config.JiraTimeout)
+}
+
+func (h *MockConfig) GetJiraFlavour() string {
+	return normalizeJiraFlavour(h.config.JiraFlavour)
+}
+
+func (h *MockConfig) GetBackend() string {
+	return normalizeBackend(h.config.Backend)
+}
+
+func (h *MockConfig) GetParallelism() int {
+	return normalizeParallelism(h.config.Parallelism)
+}
+
+func (h *MockConfig) GetJiraUser() *JiraUser {
+	return h.config.JiraUser
+}
+
+func (h *MockConfig) GetLastWorklog() *LastWorklog {
+	return h.config.LastWorklog
+}
+
 func (h *MockConfig) SetJiraEmail(email string) error {
 	return h.err
 }
@@ -89,6 +113,14 @@ func (h *MockConfig) AddAlias(a, t string) error {
 	return h.err
 }
 
+func (h *MockConfig) GetAliasVisibility(a string) string {
+	return h.config.AliasVisibilities[a]
+}
+
+func (h *MockConfig) SetAliasVisibility(a, visibility string) error {
+	return h.err
+}
+
 func (h *MockConfig) GetTaskFromAlias(a string) (string, error) {
 	if val, exists := h.config.Aliases[a]; exists {
 		return val, nil
@@ -103,3 +135,108 @@ func (h *MockConfig) RemoveAlias(a string) error {
 func (h *MockConfig) SetSnapshot(s *time.Time) error {
 	return h.err
 }
+
+func (h *MockConfig) SetJiraTimeout(timeout time.Duration) error {
+	return h.err
+}
+
+func (h *MockConfig) SetJiraFlavour(flavour string) error {
+	return h.err
+}
+
+func (h *MockConfig) SetBackend(backend string) error {
+	return h.err
+}
+
+func (h *MockConfig) SetParallelism(n int) error {
+	return h.err
+}
+
+func (h *MockConfig) SetJiraUser(user *JiraUser) error {
+	return h.err
+}
+
+func (h *MockConfig) SetLastWorklog(worklog *LastWorklog) error {
+	return h.err
+}
+
+func (h *MockConfig) GetTasksJQL() string {
+	return normalizeTasksJQL(h.config.TasksJQL)
+}
+
+func (h *MockConfig) GetQueries() map[string]string {
+	return h.config.Queries
+}
+
+func (h *MockConfig) GetQuery(name string) (string, error) {
+	if jql, exists := h.config.Queries[name]; exists {
+		return jql, nil
+	}
+	return "", ErrorQueryDontExists
+}
+
+func (h *MockConfig) SetTasksJQL(jql string) error {
+	return h.err
+}
+
+func (h *MockConfig) SetQuery(name, jql string) error {
+	return h.err
+}
+
+func (h *MockConfig) RemoveQuery(name string) error {
+	return h.err
+}
+
+func (h *MockConfig) GetBoardID() int {
+	return h.config.BoardID
+}
+
+func (h *MockConfig) SetBoardID(id int) error {
+	return h.err
+}
+
+func (h *MockConfig) GetConnection() Connection {
+	if h.config.Connection == nil {
+		return Connection{}
+	}
+	return *h.config.Connection
+}
+
+func (h *MockConfig) SetConnection(connection Connection) error {
+	return h.err
+}
+
+func (h *MockConfig) GetAuthMode() string {
+	return normalizeAuthMode(h.config.AuthMode)
+}
+
+func (h *MockConfig) GetJiraUsername() string {
+	return h.config.JiraUsername
+}
+
+func (h *MockConfig) GetOAuth() OAuth {
+	if h.config.OAuth == nil {
+		return OAuth{}
+	}
+	return *h.config.OAuth
+}
+
+func (h *MockConfig) SetAuthMode(mode string) error {
+	return h.err
+}
+
+func (h *MockConfig) SetJiraUsername(username string) error {
+	return h.err
+}
+
+func (h *MockConfig) SetOAuth(oauth OAuth) error {
+	return h.err
+}
+
+func (h *MockConfig) GetServerInfo() *ServerInfo {
+	return h.config.ServerInfo
+}
+
+func (h *MockConfig) SetServerInfo(info *ServerInfo) error {
+	return h.err
+}
@@ -0,0 +1,77 @@
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/FilipFl/logit/internal/oauth"
+	"github.com/FilipFl/logit/internal/prompter"
+)
+
+const oauthAuthorizationTimeout = 5 * time.Minute
+
+const oauthApplicationLinkHint = "OAuth needs application link in Jira with incoming authentication set up with consumer key of Your choice and public key of RSA key pair. Logit signs requests with its private key."
+
+// authorizeOAuth walks user through OAuth 1.0a dance - obtains request token,
+// lets user approve it in the browser, exchanges it for access token and stores it.
+// Tokens are exchanged with client built by newHTTPClient, so that connection
+// settings apply to them like to every other request to Jira.
+func authorizeOAuth(ctx context.Context, config Config, prompter prompter.Prompter, newHTTPClient func() (*http.Client, error)) error {
+	client, err := newHTTPClient()
+	if err != nil {
+		return err
+	}
+	origin := config.GetJiraOrigin()
+	consumerKey, err := prompter.PromptForString(oauthApplicationLinkHint, "Please enter consumer key of application link: ")
+	if err != nil {
+		return err
+	}
+	keyPath, err := prompter.PromptForString("", "Please enter path to PEM private key: ")
+	if err != nil {
+		return err
+	}
+	// key path is stored absolute so that logit works from any directory
+	keyPath, err = filepath.Abs(keyPath)
+	if err != nil {
+		return err
+	}
+	key, err := oauth.LoadPrivateKey(keyPath)
+	if err != nil {
+		return err
+	}
+	signer := oauth.NewSigner(consumerKey, key)
+
+	callback, err := oauth.ListenForCallback()
+	if err != nil {
+		return err
+	}
+	defer callback.Close()
+
+	requestToken, err := oauth.RequestToken(ctx, client, origin, signer, callback.URL())
+	if err != nil {
+		return err
+	}
+	callback.Expect(requestToken)
+	fmt.Println("Open following page in the browser and allow access:")
+	fmt.Println(oauth.AuthorizeURL(origin, requestToken))
+
+	ctx, cancel := context.WithTimeout(ctx, oauthAuthorizationTimeout)
+	defer cancel()
+	verifier, err := callback.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	accessToken, err := oauth.AccessToken(ctx, client, origin, signer, requestToken, verifier)
+	if err != nil {
+		return err
+	}
+	return config.SetOAuth(OAuth{
+		ConsumerKey:    consumerKey,
+		PrivateKeyPath: keyPath,
+		AccessToken:    accessToken.Token,
+		TokenSecret:    accessToken.Secret,
+	})
+}
@@ -0,0 +1,64 @@
+package jira
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ADFNode is a node of Atlassian Document Format used by Jira Cloud REST API v3
+// for rich text fields like comments.
+type ADFNode struct {
+	Type    string    `json:"type"`
+	Version int       `json:"version,omitempty"`
+	Text    string    `json:"text,omitempty"`
+	Content []ADFNode `json:"content,omitempty"`
+}
+
+// NewADFDocument converts plain text into ADF document with a paragraph per line.
+func NewADFDocument(text string) *ADFNode {
+	doc := &ADFNode{Type: "doc", Version: 1, Content: []ADFNode{}}
+	for _, line := range strings.Split(text, "\n") {
+		paragraph := ADFNode{Type: "paragraph"}
+		if line != "" {
+			paragraph.Content = []ADFNode{{Type: "text", Text: line}}
+		}
+		doc.Content = append(doc.Content, paragraph)
+	}
+	return doc
+}
+
+// PlainText extracts text from ADF node, paragraphs are separated by new lines.
+func (n ADFNode) PlainText() string {
+	switch n.Type {
+	case "text":
+		return n.Text
+	case "hardBreak":
+		return "\n"
+	}
+	parts := []string{}
+	for _, child := range n.Content {
+		parts = append(parts, child.PlainText())
+	}
+	if n.Type == "doc" {
+		return strings.Join(parts, "\n")
+	}
+	return strings.Join(parts, "")
+}
+
+// RichText is a text of rich text field, which is a plain string in REST API v2
+// and ADF document in v3.
+type RichText string
+
+func (t *RichText) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*t = RichText(text)
+		return nil
+	}
+	var doc ADFNode
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	*t = RichText(doc.PlainText())
+	return nil
+}
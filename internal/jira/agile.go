@@ -0,0 +1,207 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const agilePageSize = 50
+
+// Sprint states as returned by Jira Software.
+const (
+	SprintActive = "active"
+	SprintClosed = "closed"
+	SprintFuture = "future"
+)
+
+// Sprint is a sprint of Jira Software board. Dates are nil for future sprints.
+type Sprint struct {
+	ID           int        `json:"id"`
+	Name         string     `json:"name"`
+	State        string     `json:"state"`
+	Goal         string     `json:"goal"`
+	StartDate    *time.Time `json:"startDate"`
+	EndDate      *time.Time `json:"endDate"`
+	CompleteDate *time.Time `json:"completeDate"`
+}
+
+// contains tells whether moment falls between start of the sprint and its
+// completion or, for active sprint, its planned end.
+func (s Sprint) contains(moment time.Time) bool {
+	if s.StartDate != nil && moment.Before(*s.StartDate) {
+		return false
+	}
+	end := s.CompleteDate
+	if end == nil && s.State != SprintActive {
+		end = s.EndDate
+	}
+	return end == nil || !moment.After(*end)
+}
+
+// SprintLoggedTime sums time current user logged on issues of a sprint while it lasted.
+type SprintLoggedTime struct {
+	Sprint Sprint
+	Total  time.Duration
+	Tasks  []TaskLog
+}
+
+type sprintsPage struct {
+	Total  int      `json:"total"`
+	IsLast bool     `json:"isLast"`
+	Values []Sprint `json:"values"`
+}
+
+type sprintIssuesPage struct {
+	Total  int         `json:"total"`
+	Issues []JiraIssue `json:"issues"`
+}
+
+// GetSprints lists sprints of board in given states, oldest first. No states
+// lists sprints in any state.
+func (c *JiraClient) GetSprints(ctx context.Context, boardID int, states ...string) ([]Sprint, error) {
+	page, err := c.getSprintsPage(ctx, boardID, 0, states)
+	if err != nil {
+		return nil, err
+	}
+	return c.getSprintsFrom(ctx, boardID, page, 0, states)
+}
+
+// GetRecentSprints lists at most count most recent sprints of board in given
+// states, oldest first. Jira lists sprints oldest first, so when the board
+// reports its total, paging starts count sprints before the end instead of
+// reading the whole board history.
+func (c *JiraClient) GetRecentSprints(ctx context.Context, boardID, count int, states ...string) ([]Sprint, error) {
+	page, err := c.getSprintsPage(ctx, boardID, 0, states)
+	if err != nil {
+		return nil, err
+	}
+	startAt := 0
+	if skip := page.Total - count; !page.IsLast && skip > len(page.Values) {
+		startAt = skip
+		if page, err = c.getSprintsPage(ctx, boardID, startAt, states); err != nil {
+			return nil, err
+		}
+	}
+	sprints, err := c.getSprintsFrom(ctx, boardID, page, startAt, states)
+	if err != nil {
+		return nil, err
+	}
+	return sprints[max(len(sprints)-count, 0):], nil
+}
+
+// getSprintsFrom collects first page fetched at startAt and all pages after it.
+func (c *JiraClient) getSprintsFrom(ctx context.Context, boardID int, page sprintsPage, startAt int, states []string) ([]Sprint, error) {
+	sprints := []Sprint{}
+	for {
+		sprints = append(sprints, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			return sprints, nil
+		}
+		var err error
+		if page, err = c.getSprintsPage(ctx, boardID, startAt, states); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *JiraClient) getSprintsPage(ctx context.Context, boardID, startAt int, states []string) (sprintsPage, error) {
+	endpoint := fmt.Sprintf("/rest/agile/1.0/board/%d/sprint?startAt=%d&maxResults=%d", boardID, startAt, agilePageSize)
+	if len(states) > 0 {
+		endpoint += "&state=" + strings.Join(states, ",")
+	}
+	var page sprintsPage
+	err := c.getAgilePage(ctx, endpoint, &page)
+	return page, err
+}
+
+// GetSprintIssues lists issues of sprint matching jql, empty jql lists all of them.
+func (c *JiraClient) GetSprintIssues(ctx context.Context, sprintID int, jql string) ([]Issue, error) {
+	issues, err := c.getSprintJiraIssues(ctx, sprintID, jql)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		results = append(results, Issue{Key: issue.Key, Summary: issue.Fields.Summary, Status: issue.Fields.Status.Name})
+	}
+	return results, nil
+}
+
+// GetSprintLoggedTime sums current user's worklogs started during sprint on its issues.
+func (c *JiraClient) GetSprintLoggedTime(ctx context.Context, sprint Sprint) (SprintLoggedTime, error) {
+	user, err := c.GetCurrentUser(ctx, false)
+	if err != nil {
+		return SprintLoggedTime{}, fmt.Errorf("%w: %w", errorIdentifyingUser, err)
+	}
+	issues, err := c.getSprintJiraIssues(ctx, sprint.ID, "worklogAuthor = currentUser()", worklogField)
+	if err != nil {
+		return SprintLoggedTime{}, fmt.Errorf("%w: %w", errorFetchingWorklogs, err)
+	}
+	result := SprintLoggedTime{Sprint: sprint, Tasks: []TaskLog{}}
+	failed := map[string]error{}
+	for fetched := range c.fetchIssuesWorklogs(ctx, issues, 0) {
+		if fetched.err != nil {
+			failed[fetched.issue.Key] = fetched.err
+			continue
+		}
+		task := TaskLog{TaskKey: fetched.issue.Key, Summary: fetched.issue.Fields.Summary}
+		for _, log := range fetched.worklogs {
+			started, err := time.Parse(jiraTimeFormat, log.Started)
+			if err != nil || !isSameUser(user, log.Author) || !sprint.contains(started) {
+				continue
+			}
+			task.LoggedTime += time.Duration(log.TimeSpentSeconds) * time.Second
+		}
+		if task.LoggedTime > 0 {
+			result.Total += task.LoggedTime
+			result.Tasks = append(result.Tasks, task)
+		}
+	}
+	slices.SortFunc(result.Tasks, func(a, b TaskLog) int {
+		return strings.Compare(a.TaskKey, b.TaskKey)
+	})
+	if len(failed) > 0 {
+		return result, &PartialResultError{Total: len(issues), Failed: failed, Err: ctx.Err()}
+	}
+	return result, nil
+}
+
+func (c *JiraClient) getSprintJiraIssues(ctx context.Context, sprintID int, jql string, fields ...string) ([]JiraIssue, error) {
+	fields = append([]string{"summary", "status"}, fields...)
+	issues := []JiraIssue{}
+	for startAt := 0; ; {
+		endpoint := fmt.Sprintf("/rest/agile/1.0/sprint/%d/issue?startAt=%d&maxResults=%d&fields=%s", sprintID, startAt, agilePageSize, strings.Join(fields, ","))
+		if jql != "" {
+			endpoint += "&jql=" + url.QueryEscape(jql)
+		}
+		var page sprintIssuesPage
+		if err := c.getAgilePage(ctx, endpoint, &page); err != nil {
+			return nil, err
+		}
+		issues = append(issues, page.Issues...)
+		startAt += len(page.Issues)
+		if startAt >= page.Total || len(page.Issues) == 0 {
+			return issues, nil
+		}
+	}
+}
+
+func (c *JiraClient) getAgilePage(ctx context.Context, endpoint string, page any) error {
+	resp, err := c.callGet(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return c.newAPIError(resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(page)
+}
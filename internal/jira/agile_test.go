@@ -0,0 +1,169 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSprints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/agile/1.0/board/42/sprint", r.URL.Path)
+		assert.Equal(t, "active,closed", r.URL.Query().Get("state"))
+		if r.URL.Query().Get("startAt") == "0" {
+			w.Write([]byte(`{"isLast": false, "values": [{"id": 1, "name": "Sprint 1", "state": "closed"}]}`))
+			return
+		}
+		assert.Equal(t, "1", r.URL.Query().Get("startAt"))
+		w.Write([]byte(`{"isLast": true, "values": [{"id": 2, "name": "Sprint 2", "state": "active",
+			"startDate": "2024-03-01T09:00:00.000Z", "endDate": "2024-03-15T09:00:00.000Z"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	}), nil)
+	sprints, err := client.GetSprints(context.Background(), 42, SprintActive, SprintClosed)
+	assert.NoError(t, err)
+	assert.Len(t, sprints, 2)
+	assert.Equal(t, "Sprint 2", sprints[1].Name)
+	assert.Equal(t, time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC), sprints[1].EndDate.UTC())
+}
+
+func TestGetRecentSprints(t *testing.T) {
+	tests := []struct {
+		name          string
+		sprints       int
+		reportTotal   bool
+		count         int
+		expectedIDs   []int
+		expectedPages []string
+	}{
+		{
+			name:          "jumps to last sprints of long board",
+			sprints:       120,
+			reportTotal:   true,
+			count:         3,
+			expectedIDs:   []int{118, 119, 120},
+			expectedPages: []string{"0", "117"},
+		},
+		{
+			name:          "reads single page of short board once",
+			sprints:       10,
+			reportTotal:   true,
+			count:         3,
+			expectedIDs:   []int{8, 9, 10},
+			expectedPages: []string{"0"},
+		},
+		{
+			name:          "pages whole board without total",
+			sprints:       120,
+			count:         3,
+			expectedIDs:   []int{118, 119, 120},
+			expectedPages: []string{"0", "50", "100"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := []string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pages = append(pages, r.URL.Query().Get("startAt"))
+				startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+				page := sprintsPage{Values: []Sprint{}}
+				for id := startAt + 1; id <= tt.sprints && len(page.Values) < agilePageSize; id++ {
+					page.Values = append(page.Values, Sprint{ID: id, State: SprintClosed})
+				}
+				page.IsLast = startAt+len(page.Values) >= tt.sprints
+				if tt.reportTotal {
+					page.Total = tt.sprints
+				}
+				json.NewEncoder(w).Encode(page)
+			}))
+			defer server.Close()
+
+			client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+				JiraOrigin: server.URL,
+				JiraToken:  "token123",
+			}), nil)
+			sprints, err := client.GetRecentSprints(context.Background(), 42, tt.count, SprintActive, SprintClosed)
+			assert.NoError(t, err)
+			ids := []int{}
+			for _, sprint := range sprints {
+				ids = append(ids, sprint.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.Equal(t, tt.expectedPages, pages)
+		})
+	}
+}
+
+func TestGetSprintLoggedTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/agile/1.0/sprint/7/issue":
+			assert.Equal(t, "worklogAuthor = currentUser()", r.URL.Query().Get("jql"))
+			w.Write([]byte(`{"total": 2, "issues": [
+				{"id": "1", "key": "ABC-2", "fields": {"summary": "Second"}},
+				{"id": "2", "key": "ABC-1", "fields": {"summary": "First"}}]}`))
+		case "/rest/api/2/issue/ABC-1/worklog":
+			w.Write([]byte(`{"worklogs": [
+				{"author": {"key": "me"}, "started": "2024-03-04T09:00:00.000+0000", "timeSpentSeconds": 3600},
+				{"author": {"key": "someone"}, "started": "2024-03-04T09:00:00.000+0000", "timeSpentSeconds": 7200},
+				{"author": {"key": "me"}, "started": "2024-02-20T09:00:00.000+0000", "timeSpentSeconds": 7200}]}`))
+		case "/rest/api/2/issue/ABC-2/worklog":
+			w.Write([]byte(`{"worklogs": [
+				{"author": {"key": "me"}, "started": "2024-03-05T09:00:00.000+0000", "timeSpentSeconds": 1800},
+				{"author": {"key": "me"}, "started": "2024-03-20T09:00:00.000+0000", "timeSpentSeconds": 1800}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+		JiraUser:   &configuration.JiraUser{Key: "me"},
+	}), nil)
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	logged, err := client.GetSprintLoggedTime(context.Background(), Sprint{ID: 7, State: SprintClosed, StartDate: &start, EndDate: &end})
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, logged.Total)
+	assert.Equal(t, []TaskLog{
+		{TaskKey: "ABC-1", Summary: "First", LoggedTime: time.Hour},
+		{TaskKey: "ABC-2", Summary: "Second", LoggedTime: 30 * time.Minute},
+	}, logged.Tasks)
+}
+
+func TestSprintContains(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	completed := time.Date(2024, 3, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		sprint   Sprint
+		moment   time.Time
+		expected bool
+	}{
+		{name: "BeforeStart", sprint: Sprint{StartDate: &start, EndDate: &end}, moment: start.Add(-time.Hour), expected: false},
+		{name: "Inside", sprint: Sprint{StartDate: &start, EndDate: &end}, moment: start.Add(time.Hour), expected: true},
+		{name: "AfterEnd", sprint: Sprint{StartDate: &start, EndDate: &end, State: SprintClosed}, moment: end.Add(time.Hour), expected: false},
+		{name: "CompletedLate", sprint: Sprint{StartDate: &start, EndDate: &end, CompleteDate: &completed, State: SprintClosed}, moment: end.Add(time.Hour), expected: true},
+		{name: "ActiveOverdue", sprint: Sprint{StartDate: &start, EndDate: &end, State: SprintActive}, moment: end.Add(time.Hour), expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.sprint.contains(tt.moment))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/oauth"
+)
+
+// Authenticator adds credentials to every request sent to Jira.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// bearerAuthenticator sends Personal Access Token of Jira Server and Data Center.
+type bearerAuthenticator struct {
+	token string
+}
+
+func (a bearerAuthenticator) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", a.token))
+	return nil
+}
+
+// basicAuthenticator sends email and API token on Cloud, user name and password on Server.
+type basicAuthenticator struct {
+	username string
+	password string
+}
+
+func (a basicAuthenticator) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.username, a.password)
+	return nil
+}
+
+// oauth1Authenticator signs requests with access token of Jira application link.
+type oauth1Authenticator struct {
+	signer      *oauth.Signer
+	accessToken string
+}
+
+func (a oauth1Authenticator) Authenticate(req *http.Request) error {
+	return a.signer.Sign(req, a.accessToken, nil)
+}
+
+// newAuthenticator picks authenticator matching configured flavour and auth mode.
+func newAuthenticator(config configuration.Config) (Authenticator, error) {
+	if config.GetJiraFlavour() == configuration.FlavourCloud {
+		return basicAuthenticator{username: config.GetJiraEmail(), password: config.GetToken()}, nil
+	}
+	switch config.GetAuthMode() {
+	case configuration.AuthBasic:
+		return basicAuthenticator{username: config.GetJiraUsername(), password: config.GetToken()}, nil
+	case configuration.AuthOAuth1:
+		credentials := config.GetOAuth()
+		key, err := oauth.LoadPrivateKey(credentials.PrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		return oauth1Authenticator{signer: oauth.NewSigner(credentials.ConsumerKey, key), accessToken: credentials.AccessToken}, nil
+	}
+	return bearerAuthenticator{token: config.GetToken()}, nil
+}
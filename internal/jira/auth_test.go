@@ -0,0 +1,138 @@
+package jira
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	keyPath := writePEM(t, "oauth.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key))
+
+	tests := []struct {
+		name   string
+		cfg    configuration.Cfg
+		assert func(t *testing.T, r *http.Request)
+	}{
+		{
+			name: "Bearer",
+			cfg:  configuration.Cfg{JiraToken: "token123"},
+			assert: func(t *testing.T, r *http.Request) {
+				assert.Equal(t, "Bearer token123", r.Header.Get("Authorization"))
+			},
+		},
+		{
+			name: "Basic",
+			cfg:  configuration.Cfg{AuthMode: configuration.AuthBasic, JiraUsername: "jdoe", JiraToken: "password123"},
+			assert: func(t *testing.T, r *http.Request) {
+				username, password, ok := r.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "jdoe", username)
+				assert.Equal(t, "password123", password)
+			},
+		},
+		{
+			name: "OAuth1",
+			cfg: configuration.Cfg{AuthMode: configuration.AuthOAuth1, OAuth: &configuration.OAuth{
+				ConsumerKey:    "logit",
+				PrivateKeyPath: keyPath,
+				AccessToken:    "access123",
+			}},
+			assert: func(t *testing.T, r *http.Request) {
+				header := r.Header.Get("Authorization")
+				assert.True(t, strings.HasPrefix(header, "OAuth "))
+				assert.Contains(t, header, `oauth_consumer_key="logit"`)
+				assert.Contains(t, header, `oauth_token="access123"`)
+				assert.Contains(t, header, `oauth_signature_method="RSA-SHA1"`)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tt.assert(t, r)
+				w.Write([]byte(`{"fields": {"timetracking": {}}}`))
+			}))
+			defer server.Close()
+
+			cfg := tt.cfg
+			cfg.JiraOrigin = server.URL
+			client := NewJiraClient(configuration.NewMockConfig(&cfg), nil)
+			_, err := client.GetTimeTracking(context.Background(), "TEST-1")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+type headerAuthenticator struct{}
+
+func (headerAuthenticator) Authenticate(req *http.Request) error {
+	req.Header.Set("X-Custom-Auth", "custom")
+	return nil
+}
+
+func TestSetAuthenticator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "custom", r.Header.Get("X-Custom-Auth"))
+		assert.Empty(t, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"fields": {"timetracking": {}}}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{JiraOrigin: server.URL, JiraToken: "token123"}), nil)
+	client.SetAuthenticator(headerAuthenticator{})
+	_, err := client.GetTimeTracking(context.Background(), "TEST-1")
+	assert.NoError(t, err)
+}
+
+func TestAssertCredentialsAreConfigured(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  configuration.Cfg
+		want error
+	}{
+		{name: "token", cfg: configuration.Cfg{JiraToken: "token123"}},
+		{name: "token missing", cfg: configuration.Cfg{}, want: errorTokenNotConfigured},
+		{name: "basic", cfg: configuration.Cfg{AuthMode: configuration.AuthBasic, JiraUsername: "jdoe", JiraToken: "password"}},
+		{name: "basic without user name", cfg: configuration.Cfg{AuthMode: configuration.AuthBasic, JiraToken: "password"}, want: errorUsernameNotConfigured},
+		{name: "basic without password", cfg: configuration.Cfg{AuthMode: configuration.AuthBasic, JiraUsername: "jdoe"}, want: errorTokenNotConfigured},
+		{name: "oauth1", cfg: configuration.Cfg{AuthMode: configuration.AuthOAuth1, OAuth: &configuration.OAuth{ConsumerKey: "logit", PrivateKeyPath: "key.pem", AccessToken: "access"}}},
+		{name: "oauth1 not authorized", cfg: configuration.Cfg{AuthMode: configuration.AuthOAuth1, OAuth: &configuration.OAuth{ConsumerKey: "logit", PrivateKeyPath: "key.pem"}}, want: errorOAuthNotConfigured},
+		{name: "oauth1 on cloud", cfg: configuration.Cfg{AuthMode: configuration.AuthOAuth1, JiraFlavour: configuration.FlavourCloud}, want: errorAuthModeNotSupportedByCloud},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewJiraClient(configuration.NewMockConfig(&tt.cfg), nil)
+			assert.Equal(t, tt.want, client.assertCredentialsAreConfigured())
+		})
+	}
+}
+
+func TestAPIError_AuthMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin:   server.URL,
+		AuthMode:     configuration.AuthBasic,
+		JiraUsername: "jdoe",
+		JiraToken:    "password123",
+	}), nil)
+	_, err := client.GetTimeTracking(context.Background(), "TEST-1")
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, configuration.AuthBasic, apiErr.AuthMode)
+}
@@ -2,197 +2,326 @@ package jira
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/FilipFl/logit/internal/cache"
 	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/progress"
 )
 
+const jiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+
 type JiraClient struct {
-	config configuration.Config
+	config       configuration.Config
+	worklogCache cache.WorklogCache
+	retry        retryPolicy
+	// client is built once on first request, as its transport loads
+	// certificates from disk. Every request to Jira goes through it.
+	clientOnce sync.Once
+	client     *http.Client
+	clientErr  error
+	tracer     *Tracer
+	// authenticator overrides authentication picked from config when set.
+	authenticator Authenticator
+	// capabilities are probed on first request needing them.
+	capabilitiesMu    sync.Mutex
+	capabilities      *capabilities
+	capabilitiesProbe *capabilitiesProbe
 }
 
 type Worklog struct {
 	TimeSpent string `json:"timeSpent"`
 	Started   string `json:"started"`
-	Comment   string `json:"comment,omitempty"`
+	// Comment is a plain string for REST API v2 and ADF document for v3.
+	Comment    any         `json:"comment,omitempty"`
+	Visibility *Visibility `json:"visibility,omitempty"`
 }
 
 type SearchJql struct {
-	Fields     []string `json:"fields"`
-	MaxResults int      `json:"maxResults"`
-	JQL        string   `json:"jql"`
-	StartAt    int      `json:"startAt"`
+	Fields        []string `json:"fields"`
+	MaxResults    int      `json:"maxResults"`
+	JQL           string   `json:"jql"`
+	StartAt       int      `json:"startAt,omitempty"`
+	NextPageToken string   `json:"nextPageToken,omitempty"`
 }
 
-func NewJiraClient(config configuration.Config) *JiraClient {
+// NewJiraClient creates client talking to Jira configured in config. When
+// worklogCache is nil worklogs are always fetched from Jira.
+func NewJiraClient(config configuration.Config, worklogCache cache.WorklogCache) *JiraClient {
 	return &JiraClient{
-		config,
+		config:       config,
+		worklogCache: worklogCache,
+		retry:        defaultRetryPolicy(),
 	}
 }
 
-func (c *JiraClient) LogTime(taskKey string, duration time.Duration, started time.Time, comment string) error {
-	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/worklog", taskKey)
+// LogTime logs work on task and returns ID of created worklog.
+func (c *JiraClient) LogTime(ctx context.Context, taskKey string, duration time.Duration, started time.Time, comment string, options LogTimeOptions) (string, error) {
+	if err := options.Adjustment.Validate(); err != nil {
+		return "", err
+	}
+	if len(options.Attributes) > 0 {
+		return "", errorAttributesRequireTempo
+	}
+	endpoint := c.apiPath(fmt.Sprintf("/issue/%s/worklog", taskKey)) + options.Adjustment.query("reduceBy")
 	timeSpent := fmt.Sprintf("%dh %dm", int(duration.Hours()), int(duration.Minutes())%60)
 	worklog := Worklog{
-		TimeSpent: timeSpent,
-		Started:   started.Format("2006-01-02T15:04:05.000-0700"),
-		Comment:   comment,
+		TimeSpent:  timeSpent,
+		Started:    started.Format(jiraTimeFormat),
+		Visibility: options.Visibility,
+	}
+	if comment != "" {
+		worklog.Comment = c.richText(comment)
 	}
 	jsonData, err := json.Marshal(worklog)
 	if err != nil {
-		return err
+		return "", err
 	}
-	resp, err := c.callPost(endpoint, jsonData, c.assertConfigurationIsValid)
+	resp, err := c.callPost(ctx, endpoint, jsonData, retryRejectedOnly, c.assertConfigurationIsValid)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to log time: %s", string(body))
+		return "", fmt.Errorf("failed to log time: %w", c.newAPIError(resp))
 	}
-	return nil
+	var created JiraIssueWorklog
+	// worklog is logged already, missing ID only disables amending it later
+	json.NewDecoder(resp.Body).Decode(&created)
+	c.cacheWrittenWorklog(created)
+	return created.ID, nil
 }
 
-func (c *JiraClient) GetAssignedIssues() ([]Issue, error) {
-	endpoint := "/rest/api/2/search"
-	data := SearchJql{
-		Fields:     []string{"key", "summary", "status", "assignee"},
-		JQL:        "assignee = currentUser() AND status not in (Done, Closed)",
-		MaxResults: 100,
-		StartAt:    0,
-	}
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.callPost(endpoint, jsonData, c.assertConfigurationIsValid)
+func (c *JiraClient) GetTimeTracking(ctx context.Context, taskKey string) (TimeTracking, error) {
+	resp, err := c.callGet(ctx, c.apiPath(fmt.Sprintf("/issue/%s?fields=timetracking", taskKey)))
 	if err != nil {
-		return nil, err
+		return TimeTracking{}, err
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, errorFailedToReadBody
+	if resp.StatusCode != http.StatusOK {
+		return TimeTracking{}, c.newAPIError(resp)
 	}
-	if resp.StatusCode == http.StatusOK {
-		issuesResults := make([]Issue, 0)
-		var result Result
-		err := json.Unmarshal(body, &result)
-		if err != nil {
-			return nil, err
-		}
-		for _, issue := range result.Issues {
-			issueResult := Issue{}
-			issueResult.Key = issue.Key
-			issueResult.Summary = issue.Fields.Summary
-			issueResult.Status = issue.Fields.Status.Name
-			issuesResults = append(issuesResults, issueResult)
-		}
-		return issuesResults, nil
+	var issue JiraIssue
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return TimeTracking{}, err
+	}
+	return issue.Fields.TimeTracking, nil
+}
 
+// GetAssignedIssues lists tasks matching tasks JQL from config.
+func (c *JiraClient) GetAssignedIssues(ctx context.Context) ([]Issue, error) {
+	issues, err := c.SearchIssues(ctx, c.config.GetTasksJQL())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errorFetchingAssignedIssues, err)
 	}
+	return issues, nil
+}
 
-	return nil, errorFetchingAssignedIssues
+// SearchIssues lists all issues matching JQL query.
+func (c *JiraClient) SearchIssues(ctx context.Context, jql string) ([]Issue, error) {
+	it := c.newSearchIterator(
+		jql,
+		[]string{"key", "summary", "status", "assignee"},
+		c.assertConfigurationIsValid,
+	)
+	issuesResults := make([]Issue, 0)
+	for it.Next(ctx) {
+		issue := it.Issue()
+		issueResult := Issue{}
+		issueResult.Key = issue.Key
+		issueResult.Summary = issue.Fields.Summary
+		issueResult.Status = issue.Fields.Status.Name
+		issuesResults = append(issuesResults, issueResult)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return issuesResults, nil
 }
 
-func (c *JiraClient) GetLoggedTime(fromDays int) (Logs, error) {
-	resultLogs := Logs{}
-	endpoint := "/rest/api/2/search"
-	data := SearchJql{
-		Fields:     []string{"key", "summary"},
-		JQL:        fmt.Sprintf("worklogAuthor = currentUser() AND worklogDate > -%dd", fromDays),
-		MaxResults: 100,
-		StartAt:    0,
-	}
-	jsonData, err := json.Marshal(data)
+func (c *JiraClient) GetLoggedTime(ctx context.Context, query LoggedTimeQuery) (Logs, error) {
+	user, err := c.GetCurrentUser(ctx, false)
 	if err != nil {
-		return resultLogs, err
+		return Logs{}, fmt.Errorf("%w: %w", errorIdentifyingUser, err)
 	}
-	resp, err := c.callPost(endpoint, jsonData, c.assertConfigurationForFetchingWorklogsIsValid)
-	if err != nil {
-		return resultLogs, err
+	boundary := time.Now().AddDate(0, 0, -(query.FromDays))
+	var worklogs []cache.Worklog
+	if c.worklogCache != nil {
+		worklogs, err = c.getCachedUserWorklogs(ctx, user, query)
+	} else {
+		worklogs, err = c.fetchUserWorklogs(ctx, user, query.FromDays, query.Reporter)
 	}
-	defer resp.Body.Close()
+	return logsFromWorklogs(worklogs, boundary), err
+}
 
-	body, err := io.ReadAll(resp.Body)
+// fetchUserWorklogs searches for issues user logged time on in last fromDays
+// and collects user's worklogs from each of them.
+func (c *JiraClient) fetchUserWorklogs(ctx context.Context, user configuration.JiraUser, fromDays int, reporter progress.Reporter) ([]cache.Worklog, error) {
+	it := c.newSearchIterator(
+		fmt.Sprintf("worklogAuthor = currentUser() AND worklogDate > -%dd", fromDays),
+		[]string{"key", "summary", worklogField},
+		c.assertConfigurationIsValid,
+	)
+	issues, err := collectIssues(ctx, it)
 	if err != nil {
-		return resultLogs, errorFailedToReadBody
+		return nil, fmt.Errorf("%w: %w", errorFetchingWorklogs, err)
 	}
-	if resp.StatusCode != http.StatusOK {
-		return resultLogs, errorFetchingAssignedIssues
+
+	worklogs := []cache.Worklog{}
+	failed := map[string]error{}
+	fromDaysDuration := time.Hour * time.Duration(fromDays) * 24
+	reporter.Start(len(issues))
+	defer reporter.Finish()
+	for fetched := range c.fetchIssuesWorklogs(ctx, issues, fromDaysDuration) {
+		reporter.Increment()
+		if fetched.err != nil {
+			failed[fetched.issue.Key] = fetched.err
+			continue
+		}
+		for _, log := range fetched.worklogs {
+			if !isSameUser(user, log.Author) {
+				continue
+			}
+			worklog, err := toCachedWorklog(log, fetched.issue)
+			if err != nil {
+				continue
+			}
+			worklogs = append(worklogs, worklog)
+		}
 	}
 
-	var result Result
-	err = json.Unmarshal(body, &result)
+	if len(failed) > 0 {
+		return worklogs, &PartialResultError{
+			Total:  len(issues),
+			Failed: failed,
+			Err:    ctx.Err(),
+		}
+	}
+	return worklogs, nil
+}
+
+func toCachedWorklog(log JiraIssueWorklog, issue JiraIssue) (cache.Worklog, error) {
+	startTime, err := time.Parse(jiraTimeFormat, log.Started)
 	if err != nil {
-		return resultLogs, err
+		return cache.Worklog{}, err
+	}
+	return cache.Worklog{
+		ID:               log.ID,
+		IssueID:          issue.ID,
+		IssueKey:         issue.Key,
+		IssueSummary:     issue.Fields.Summary,
+		Started:          startTime,
+		TimeSpentSeconds: log.TimeSpentSeconds,
+		Visibility:       log.Visibility.String(),
+	}, nil
+}
+
+func logsFromWorklogs(worklogs []cache.Worklog, boundary time.Time) Logs {
+	resultLogs := Logs{}
+	for _, log := range worklogs {
+		if log.Started.Truncate(24 * time.Hour).Before(boundary) {
+			continue
+		}
+		worklog := TaskLog{
+			Summary:    log.IssueSummary,
+			LoggedTime: time.Duration(log.TimeSpentSeconds) * time.Second,
+			TaskKey:    log.IssueKey,
+		}
+		if log.Visibility != "" {
+			worklog.Visibilities = []string{log.Visibility}
+		}
+		resultLogs.AddLog(worklog, log.Started)
 	}
+	return resultLogs
+}
+
+// worklogField makes search embed worklogs of found issues.
+const worklogField = "worklog"
 
+type issueWorklogs struct {
+	issue    JiraIssue
+	worklogs []JiraIssueWorklog
+	err      error
+}
+
+// fetchIssuesWorklogs fetches worklogs of issues running at most configured
+// number of requests at once. Issues found with complete worklog field are
+// served without any request, as searching with worklogField embeds up to 20
+// worklogs of each issue. Results are sent in order of completion and the
+// channel is closed after all issues are processed.
+func (c *JiraClient) fetchIssuesWorklogs(ctx context.Context, issues []JiraIssue, days time.Duration) <-chan issueWorklogs {
+	embedded := []JiraIssue{}
+	truncated := []JiraIssue{}
+	for _, issue := range issues {
+		if issue.Fields.Worklog.isComplete() {
+			embedded = append(embedded, issue)
+		} else {
+			truncated = append(truncated, issue)
+		}
+	}
+	jobs := make(chan JiraIssue)
+	results := make(chan issueWorklogs)
 	var wg sync.WaitGroup
-	fromDaysDuration := time.Hour * time.Duration(fromDays) * 24
-	fromDaysBoundaryTime := time.Now().AddDate(0, 0, -(fromDays))
-	i := 0
-	for _, issue := range result.Issues {
+	for range min(c.config.GetParallelism(), len(truncated)) {
 		wg.Add(1)
-		go func(issue JiraIssue) {
+		go func() {
 			defer wg.Done()
-
-			logs, err := c.getAllWorklogs(issue.Key, fromDaysDuration)
-			if err != nil {
-				fmt.Printf("Error fetching logs for %s: %v\n", issue.Key, err)
-				return
-			}
-
-			for _, log := range logs {
-				if strings.ToLower(log.Author.Email) != strings.ToLower(c.config.GetJiraEmail()) {
-					continue
-				}
-				startTime, err := time.Parse("2006-01-02T15:04:05.000-0700", log.Started)
-				if err != nil {
-					continue
-				}
-				if startTime.Truncate(24 * time.Hour).Before(fromDaysBoundaryTime) {
-					continue
-				}
-				worklog := TaskLog{
-					Summary:    issue.Fields.Summary,
-					LoggedTime: time.Duration(log.TimeSpentSeconds) * time.Second,
-					TaskKey:    issue.Key,
-				}
-				resultLogs.AddLog(worklog, startTime)
+			for issue := range jobs {
+				logs, err := c.getAllWorklogs(ctx, issue.Key, days)
+				results <- issueWorklogs{issue: issue, worklogs: logs, err: err}
 			}
-		}(issue)
-		i++
-		fmt.Printf("Completed fetching %d/%d tasks.\n", i, len(result.Issues))
+		}()
 	}
-
-	wg.Wait()
-	return resultLogs, nil
+	go func() {
+		for _, issue := range embedded {
+			results <- issueWorklogs{issue: issue, worklogs: issue.Fields.Worklog.Worklogs}
+		}
+		for _, issue := range truncated {
+			jobs <- issue
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+	return results
 }
 
-func (c *JiraClient) getAllWorklogs(issueKey string, days time.Duration) ([]JiraIssueWorklog, error) {
+// getAllWorklogs fetches worklogs of issue started in last days, zero days
+// fetches all of them.
+func (c *JiraClient) getAllWorklogs(ctx context.Context, issueKey string, days time.Duration) ([]JiraIssueWorklog, error) {
 	startAt := 0
 	pageSize := 5000
 	allWorklogs := []JiraIssueWorklog{}
-	startedAfter := time.Now().Add(-1 * days).Unix()
+	filter := ""
+	// Jira versions not supporting startedAfter ignore it, callers filter worklogs by start anyway
+	if days > 0 {
+		// startedAfter is in milliseconds, value in seconds matches every worklog
+		filter = fmt.Sprintf("&startedAfter=%d", time.Now().Add(-1*days).UnixMilli())
+	}
 	for {
-		endpoint := fmt.Sprintf("/rest/api/2/issue/%s/worklog?startAt=%d&maxResults=%d&startedAfter=%d", issueKey, startAt, pageSize, startedAfter)
-		resp, err := c.callGet(endpoint)
+		endpoint := c.apiPath(fmt.Sprintf("/issue/%s/worklog?startAt=%d&maxResults=%d%s", issueKey, startAt, pageSize, filter))
+		resp, err := c.callGet(ctx, endpoint)
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			apiErr := c.newAPIError(resp)
+			resp.Body.Close()
+			return nil, apiErr
+		}
 
 		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
 			return nil, err
 		}
@@ -213,50 +342,166 @@ func (c *JiraClient) getAllWorklogs(issueKey string, days time.Duration) ([]Jira
 	return allWorklogs, nil
 }
 
-func (c *JiraClient) callPost(endpoint string, jsonData []byte, validateFunc func() error) (*http.Response, error) {
+func (c *JiraClient) callPost(ctx context.Context, endpoint string, jsonData []byte, mode retryMode, validateFunc func() error) (*http.Response, error) {
 	err := validateFunc()
 	if err != nil {
 		return nil, err
 	}
-	url := fmt.Sprintf("%s%s", c.config.GetJiraOrigin(), endpoint)
+	return c.call(ctx, "POST", endpoint, jsonData, mode)
+}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+func (c *JiraClient) callPut(ctx context.Context, endpoint string, jsonData []byte) (*http.Response, error) {
+	err := c.assertConfigurationIsValid()
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.config.GetToken()))
-	client := &http.Client{}
-	return client.Do(req)
+	return c.call(ctx, "PUT", endpoint, jsonData, retryIdempotent)
 }
 
-func (c *JiraClient) callGet(endpoint string) (*http.Response, error) {
+func (c *JiraClient) callDelete(ctx context.Context, endpoint string) (*http.Response, error) {
 	err := c.assertConfigurationIsValid()
 	if err != nil {
 		return nil, err
 	}
-	url := fmt.Sprintf("%s%s", c.config.GetJiraOrigin(), endpoint)
+	// retried delete of already deleted worklog would end with misleading 404
+	return c.call(ctx, "DELETE", endpoint, nil, retryRejectedOnly)
+}
 
-	req, err := http.NewRequest("GET", url, nil)
+func (c *JiraClient) callGet(ctx context.Context, endpoint string) (*http.Response, error) {
+	err := c.assertConfigurationIsValid()
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.config.GetToken()))
-	client := &http.Client{}
-	return client.Do(req)
+	return c.call(ctx, "GET", endpoint, nil, retryIdempotent)
 }
 
-func (c *JiraClient) assertConfigurationIsValid() error {
-	token := c.config.GetToken()
-	if token == "" {
-		if c.config.GetJiraTokenEnvName() != "" {
-			return errorTokenEnvNameSetButEmpty
+func (c *JiraClient) call(ctx context.Context, method, endpoint string, jsonData []byte, mode retryMode) (*http.Response, error) {
+	url := fmt.Sprintf("%s%s", c.config.GetJiraOrigin(), endpoint)
+	client, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	authenticator, err := c.getAuthenticator()
+	if err != nil {
+		return nil, err
+	}
+	return c.retry.do(ctx, client, mode, func() (*http.Request, error) {
+		var body io.Reader
+		if jsonData != nil {
+			body = bytes.NewReader(jsonData)
 		}
-		return errorTokenNotConfigured
+		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		if err := authenticator.Authenticate(req); err != nil {
+			return nil, err
+		}
+		return req, nil
+	})
+}
+
+// SetAuthenticator replaces authentication configured in config.
+func (c *JiraClient) SetAuthenticator(authenticator Authenticator) {
+	c.authenticator = authenticator
+}
+
+func (c *JiraClient) getAuthenticator() (Authenticator, error) {
+	if c.authenticator != nil {
+		return c.authenticator, nil
+	}
+	return newAuthenticator(c.config)
+}
+
+// newAPIError describes unsuccessful response noting authentication mode, so
+// that rejected credentials can be explained.
+func (c *JiraClient) newAPIError(resp *http.Response) *APIError {
+	apiErr := newAPIError(resp)
+	apiErr.AuthMode = c.config.GetAuthMode()
+	return apiErr
+}
+
+func (c *JiraClient) isCloud() bool {
+	return c.config.GetJiraFlavour() == configuration.FlavourCloud
+}
+
+// apiPath prefixes path with REST API root matching configured Jira flavour.
+func (c *JiraClient) apiPath(path string) string {
+	if c.isCloud() {
+		return "/rest/api/3" + path
+	}
+	return "/rest/api/2" + path
+}
+
+func (c *JiraClient) searchEndpoint(caps capabilities) string {
+	if caps.searchJQL {
+		return "/rest/api/3/search/jql"
+	}
+	return "/rest/api/2/search"
+}
+
+// richText returns text in format expected by rich text fields of configured Jira flavour.
+func (c *JiraClient) richText(text string) any {
+	if c.isCloud() {
+		return NewADFDocument(text)
+	}
+	return text
+}
+
+// SetTracer makes client report its HTTP exchanges to tracer. It has to be
+// called before the first request.
+func (c *JiraClient) SetTracer(tracer *Tracer) {
+	c.tracer = tracer
+}
+
+func (c *JiraClient) httpClient() (*http.Client, error) {
+	c.clientOnce.Do(func() {
+		transport, err := newTransport(c.config.GetConnection())
+		if err != nil {
+			c.clientErr = err
+			return
+		}
+		var roundTripper http.RoundTripper = transport
+		if c.tracer != nil {
+			roundTripper = &tracingTransport{
+				next:     transport,
+				tracer:   c.tracer,
+				redactor: newRedactor(c.config.GetToken(), c.config.GetOAuth().AccessToken, c.config.GetOAuth().TokenSecret),
+				now:      time.Now,
+			}
+		}
+		c.client = &http.Client{Timeout: c.config.GetJiraTimeout(), Transport: roundTripper}
+	})
+	return c.client, c.clientErr
+}
+
+// HTTPClient returns the client requests of JiraClient go through, with its
+// connection settings, timeout and tracing, for talking to Jira outside of its
+// REST API, e.g. during OAuth authorization. It refuses origins requests
+// wouldn't be sent to.
+func (c *JiraClient) HTTPClient() (*http.Client, error) {
+	if err := c.assertOriginIsValid(); err != nil {
+		return nil, err
+	}
+	return c.httpClient()
+}
+
+func (c *JiraClient) assertConfigurationIsValid() error {
+	if err := c.assertCredentialsAreConfigured(); err != nil {
+		return err
+	}
+	if err := c.assertOriginIsValid(); err != nil {
+		return err
+	}
+	if c.isCloud() && c.config.GetJiraEmail() == "" {
+		return errorEmailNotConfigured
 	}
+	return nil
+}
+
+func (c *JiraClient) assertOriginIsValid() error {
 	origin := c.config.GetJiraOrigin()
 	if origin == "" {
 		return errorOriginNotConfigured
@@ -264,17 +509,41 @@ func (c *JiraClient) assertConfigurationIsValid() error {
 	if !strings.HasPrefix(c.config.GetJiraOrigin(), "https://") && !strings.HasPrefix(c.config.GetJiraOrigin(), "http://") {
 		return errorNoProtocolInOrigin
 	}
+	connection := c.config.GetConnection()
+	if strings.HasPrefix(origin, "http://") && !connection.AllowInsecureHTTP {
+		if parsed, err := url.Parse(origin); err != nil || !isLoopback(parsed) {
+			return errorInsecureHTTP
+		}
+	}
+	// Atlassian Cloud always presents valid certificate, skipping verification there only hides interception
+	if c.isCloud() && connection.InsecureSkipVerify {
+		return errorInsecureSkipVerifyOnCloud
+	}
 	return nil
 }
 
-func (c *JiraClient) assertConfigurationForFetchingWorklogsIsValid() error {
-	err := c.assertConfigurationIsValid()
-	if err != nil {
-		return err
+func (c *JiraClient) assertCredentialsAreConfigured() error {
+	mode := c.config.GetAuthMode()
+	if c.isCloud() && mode != configuration.AuthToken {
+		return errorAuthModeNotSupportedByCloud
 	}
-	email := c.config.GetJiraEmail()
-	if email == "" {
-		return errorEmailNotConfigured
+	switch mode {
+	case configuration.AuthOAuth1:
+		credentials := c.config.GetOAuth()
+		if credentials.ConsumerKey == "" || credentials.PrivateKeyPath == "" || credentials.AccessToken == "" {
+			return errorOAuthNotConfigured
+		}
+		return nil
+	case configuration.AuthBasic:
+		if c.config.GetJiraUsername() == "" {
+			return errorUsernameNotConfigured
+		}
+	}
+	if c.config.GetToken() == "" {
+		if c.config.GetJiraTokenEnvName() != "" {
+			return errorTokenEnvNameSetButEmpty
+		}
+		return errorTokenNotConfigured
 	}
 	return nil
 }
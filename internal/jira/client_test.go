@@ -1,13 +1,22 @@
 package jira
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/FilipFl/logit/internal/cache"
 	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/progress"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,6 +29,7 @@ func TestLogTime_Success(t *testing.T) {
 		assert.Contains(t, string(body), "Working on task")
 
 		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "10100", "timeSpentSeconds": 5400}`))
 	}))
 	defer server.Close()
 
@@ -28,9 +38,10 @@ func TestLogTime_Success(t *testing.T) {
 		JiraToken:  "token123",
 	})
 
-	client := NewJiraClient(mockCfg)
-	err := client.LogTime("TEST-123", 90*time.Minute, time.Now(), "Working on task")
+	client := NewJiraClient(mockCfg, nil)
+	worklogID, err := client.LogTime(context.Background(), "TEST-123", 90*time.Minute, time.Now(), "Working on task", LogTimeOptions{})
 	assert.NoError(t, err)
+	assert.Equal(t, "10100", worklogID)
 }
 
 func TestLogTime_FailureStatus(t *testing.T) {
@@ -45,8 +56,8 @@ func TestLogTime_FailureStatus(t *testing.T) {
 		JiraToken:  "token123",
 	})
 
-	client := NewJiraClient(mockCfg)
-	err := client.LogTime("TEST-123", 1*time.Hour, time.Now(), "Logging failed task")
+	client := NewJiraClient(mockCfg, nil)
+	_, err := client.LogTime(context.Background(), "TEST-123", 1*time.Hour, time.Now(), "Logging failed task", LogTimeOptions{})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to log time")
 }
@@ -81,10 +92,11 @@ func TestGetAssignedIssues_Success(t *testing.T) {
 	mockCfg := configuration.NewMockConfig(&configuration.Cfg{
 		JiraOrigin: server.URL,
 		JiraToken:  "token123",
+		ServerInfo: cachedServerInfo(configuration.FlavourServer),
 	})
 
-	client := NewJiraClient(mockCfg)
-	issues, err := client.GetAssignedIssues()
+	client := NewJiraClient(mockCfg, nil)
+	issues, err := client.GetAssignedIssues(context.Background())
 	assert.NoError(t, err)
 	assert.Len(t, issues, 2)
 	assert.Equal(t, "ISSUE-1", issues[0].Key)
@@ -98,8 +110,400 @@ func TestGetAssignedIssues_HTTPError(t *testing.T) {
 		JiraToken:  "token123",
 	})
 
-	client := NewJiraClient(mockCfg)
-	issues, err := client.GetAssignedIssues()
+	client := NewJiraClient(mockCfg, nil)
+	issues, err := client.GetAssignedIssues(context.Background())
 	assert.Error(t, err)
 	assert.Nil(t, issues)
 }
+
+func TestGetAssignedIssues_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	mockCfg := configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin:  server.URL,
+		JiraToken:   "token123",
+		JiraTimeout: "50ms",
+	})
+
+	client := NewJiraClient(mockCfg, nil)
+	start := time.Now()
+	_, err := client.GetAssignedIssues(context.Background())
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestGetLoggedTime_CancelledReturnsPartialResult(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	started := time.Now().Format("2006-01-02T15:04:05.000-0700")
+	fastServed := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			w.Write([]byte(`{"issues": [{"key": "ISSUE-1", "fields": {"summary": "Fast"}}, {"key": "ISSUE-2", "fields": {"summary": "Slow"}}]}`))
+		case "/rest/api/2/issue/ISSUE-1/worklog":
+			fmt.Fprintf(w, `{"worklogs": [{"author": {"key": "me"}, "started": "%s", "timeSpentSeconds": 3600}]}`, started)
+			close(fastServed)
+		case "/rest/api/2/issue/ISSUE-2/worklog":
+			<-fastServed
+			time.Sleep(50 * time.Millisecond)
+			cancel()
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	mockCfg := configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+		JiraUser:   &configuration.JiraUser{Key: "me"},
+	})
+
+	client := NewJiraClient(mockCfg, nil)
+	logs, err := client.GetLoggedTime(ctx, LoggedTimeQuery{FromDays: 1, Reporter: progress.NewSilentReporter()})
+
+	var partialErr *PartialResultError
+	assert.True(t, errors.As(err, &partialErr))
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, []string{"ISSUE-2"}, partialErr.FailedKeys())
+	assert.Equal(t, 1, partialErr.Fetched())
+	assert.Len(t, logs.Days, 1)
+	assert.Equal(t, time.Hour, logs.Days[0].TimeLogged)
+}
+
+func TestLogTime_Cloud(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/issue/TEST-123/worklog", r.URL.Path)
+		email, token, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "me@example.com", email)
+		assert.Equal(t, "token123", token)
+
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), `"comment":{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"Working on task"}]}]}`)
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	mockCfg := configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin:  server.URL,
+		JiraToken:   "token123",
+		JiraEmail:   "me@example.com",
+		JiraFlavour: configuration.FlavourCloud,
+	})
+
+	client := NewJiraClient(mockCfg, nil)
+	_, err := client.LogTime(context.Background(), "TEST-123", 90*time.Minute, time.Now(), "Working on task", LogTimeOptions{})
+	assert.NoError(t, err)
+}
+
+func TestGetAssignedIssues_Cloud(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/search/jql", r.URL.Path)
+		_, _, ok := r.BasicAuth()
+		assert.True(t, ok)
+		body, _ := io.ReadAll(r.Body)
+		assert.NotContains(t, string(body), "startAt")
+		w.Write([]byte(`{"issues": [{"key": "ISSUE-1", "fields": {"summary": "Fix bug", "status": {"name": "To Do"}}}], "isLast": true}`))
+	}))
+	defer server.Close()
+
+	mockCfg := configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin:  server.URL,
+		JiraToken:   "token123",
+		JiraEmail:   "me@example.com",
+		JiraFlavour: configuration.FlavourCloud,
+		ServerInfo:  cachedServerInfo(configuration.FlavourCloud),
+	})
+
+	client := NewJiraClient(mockCfg, nil)
+	issues, err := client.GetAssignedIssues(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, issues, 1)
+}
+
+func TestAssertConfigurationIsValid_CloudRequiresEmail(t *testing.T) {
+	mockCfg := configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin:  "https://example.atlassian.net",
+		JiraToken:   "token123",
+		JiraFlavour: configuration.FlavourCloud,
+	})
+
+	client := NewJiraClient(mockCfg, nil)
+	assert.Equal(t, errorEmailNotConfigured, client.assertConfigurationIsValid())
+}
+
+func TestLogTime_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errorMessages": ["Worklog must not be null."], "errors": {"timeLogged": "You must indicate the time spent working."}}`))
+	}))
+	defer server.Close()
+
+	mockCfg := configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	})
+
+	client := NewJiraClient(mockCfg, nil)
+	_, err := client.LogTime(context.Background(), "TEST-123", time.Hour, time.Now(), "", LogTimeOptions{})
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "POST", apiErr.Method)
+	assert.Equal(t, "/rest/api/2/issue/TEST-123/worklog", apiErr.Endpoint)
+	assert.Equal(t, []string{"Worklog must not be null.", "timeLogged: You must indicate the time spent working."}, apiErr.Messages())
+}
+
+func TestGetAssignedIssues_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`<html>Unauthorized</html>`))
+	}))
+	defer server.Close()
+
+	mockCfg := configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	})
+
+	client := NewJiraClient(mockCfg, nil)
+	_, err := client.GetAssignedIssues(context.Background())
+
+	var apiErr *APIError
+	assert.ErrorIs(t, err, errorFetchingAssignedIssues)
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Equal(t, "<html>Unauthorized</html>", apiErr.Body)
+}
+
+func TestGetLoggedTime_BoundedConcurrency(t *testing.T) {
+	const issuesCount = 60
+	const parallelism = 3
+	started := time.Now().Format("2006-01-02T15:04:05.000-0700")
+	var inFlight, maxInFlight atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/api/2/search" {
+			issues := []string{}
+			for i := range issuesCount {
+				issues = append(issues, fmt.Sprintf(`{"key": "ISSUE-%d", "fields": {"summary": "Issue %d"}}`, i, i))
+			}
+			fmt.Fprintf(w, `{"total": %d, "issues": [%s]}`, issuesCount, strings.Join(issues, ","))
+			return
+		}
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		fmt.Fprintf(w, `{"worklogs": [
+			{"author": {"key": "me"}, "started": "%s", "timeSpentSeconds": 1800},
+			{"author": {"key": "someone"}, "started": "%s", "timeSpentSeconds": 1800}
+		]}`, started, started)
+	}))
+	defer server.Close()
+
+	mockCfg := configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin:  server.URL,
+		JiraToken:   "token123",
+		JiraUser:    &configuration.JiraUser{Key: "me"},
+		Parallelism: parallelism,
+	})
+	reporter := progress.NewMockReporter()
+
+	client := NewJiraClient(mockCfg, nil)
+	logs, err := client.GetLoggedTime(context.Background(), LoggedTimeQuery{FromDays: 1, Reporter: reporter})
+	assert.NoError(t, err)
+	assert.Len(t, logs.Days, 1)
+	assert.Len(t, logs.Days[0].Worklogs, issuesCount)
+	assert.Equal(t, issuesCount*30*time.Minute, logs.Days[0].TimeLogged)
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(parallelism))
+	assert.Equal(t, issuesCount, reporter.Total)
+	assert.Equal(t, issuesCount, reporter.Done)
+	assert.True(t, reporter.Finished)
+}
+
+func TestLogTime_AdjustEstimate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/issue/TEST-123/worklog", r.URL.Path)
+		assert.Equal(t, "adjustEstimate=manual&reduceBy=2h", r.URL.RawQuery)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "10100"}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	}), nil)
+	options := LogTimeOptions{Adjustment: EstimateAdjustment{Mode: AdjustEstimateManual, Amount: "2h"}}
+	_, err := client.LogTime(context.Background(), "TEST-123", time.Hour, time.Now(), "", options)
+	assert.NoError(t, err)
+
+	options = LogTimeOptions{Adjustment: EstimateAdjustment{Mode: AdjustEstimateNew}}
+	_, err = client.LogTime(context.Background(), "TEST-123", time.Hour, time.Now(), "", options)
+	assert.ErrorIs(t, err, errorNewEstimateRequired)
+}
+
+func TestGetTimeTracking(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/issue/TEST-123", r.URL.Path)
+		assert.Equal(t, "timetracking", r.URL.Query().Get("fields"))
+		w.Write([]byte(`{"key": "TEST-123", "fields": {"timetracking": {"originalEstimate": "1d", "remainingEstimate": "5h", "timeSpent": "3h"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	}), nil)
+	timeTracking, err := client.GetTimeTracking(context.Background(), "TEST-123")
+	assert.NoError(t, err)
+	assert.Equal(t, TimeTracking{OriginalEstimate: "1d", RemainingEstimate: "5h", TimeSpent: "3h"}, timeTracking)
+}
+
+func TestLogTime_Visibility(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var worklog map[string]any
+		json.NewDecoder(r.Body).Decode(&worklog)
+		assert.Equal(t, map[string]any{"type": "role", "value": "Developers"}, worklog["visibility"])
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	}), nil)
+	options := LogTimeOptions{Visibility: &Visibility{Type: VisibilityRole, Value: "Developers"}}
+	_, err := client.LogTime(context.Background(), "TEST-123", time.Hour, time.Now(), "", options)
+	assert.NoError(t, err)
+}
+
+func TestParseVisibility(t *testing.T) {
+	tests := []struct {
+		input         string
+		expected      *Visibility
+		expectedError error
+	}{
+		{input: "role:Developers", expected: &Visibility{Type: VisibilityRole, Value: "Developers"}},
+		{input: "Group: security team", expected: &Visibility{Type: VisibilityGroup, Value: "security team"}},
+		{input: "team:security", expectedError: errorInvalidVisibility},
+		{input: "role:", expectedError: errorInvalidVisibility},
+		{input: "Developers", expectedError: errorInvalidVisibility},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			visibility, err := ParseVisibility(tt.input)
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expected, visibility)
+		})
+	}
+}
+
+func TestLogsFromWorklogs_Visibility(t *testing.T) {
+	started := time.Now()
+	logs := logsFromWorklogs([]cache.Worklog{
+		{ID: "1", IssueKey: "SEC-1", Started: started, TimeSpentSeconds: 1800, Visibility: "group:security"},
+		{ID: "2", IssueKey: "SEC-1", Started: started, TimeSpentSeconds: 1800, Visibility: "role:Developers"},
+		{ID: "3", IssueKey: "SEC-1", Started: started, TimeSpentSeconds: 1800, Visibility: "group:security"},
+		{ID: "4", IssueKey: "PROJ-1", Started: started, TimeSpentSeconds: 3600},
+	}, started.AddDate(0, 0, -1))
+	assert.Len(t, logs.Days, 1)
+	assert.Empty(t, logs.Days[0].Worklogs[0].Visibilities)
+	assert.Equal(t, []string{"group:security", "role:Developers"}, logs.Days[0].Worklogs[1].Visibilities)
+}
+
+func TestGetLoggedTime_EmbeddedWorklogs(t *testing.T) {
+	started := time.Now().Format("2006-01-02T15:04:05.000-0700")
+	worklog := fmt.Sprintf(`{"id": "%%d", "author": {"key": "me"}, "started": "%s", "timeSpentSeconds": 60}`, started)
+	worklogs := func(from, to int) string {
+		logs := []string{}
+		for i := from; i < to; i++ {
+			logs = append(logs, fmt.Sprintf(worklog, i))
+		}
+		return strings.Join(logs, ",")
+	}
+	var worklogRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			var query SearchJql
+			json.NewDecoder(r.Body).Decode(&query)
+			assert.Contains(t, query.Fields, "worklog")
+			fmt.Fprintf(w, `{"total": 2, "issues": [
+				{"key": "ISSUE-1", "fields": {"summary": "Complete", "worklog": {"startAt": 0, "maxResults": 20, "total": 2, "worklogs": [%s]}}},
+				{"key": "ISSUE-2", "fields": {"summary": "Truncated", "worklog": {"startAt": 0, "maxResults": 20, "total": 25, "worklogs": [%s]}}}
+			]}`, worklogs(0, 2), worklogs(100, 120))
+		case "/rest/api/2/issue/ISSUE-2/worklog":
+			worklogRequests.Add(1)
+			fmt.Fprintf(w, `{"startAt": 0, "maxResults": 5000, "total": 25, "worklogs": [%s]}`, worklogs(100, 125))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+		JiraUser:   &configuration.JiraUser{Key: "me"},
+		ServerInfo: cachedServerInfo(configuration.FlavourServer),
+	}), nil)
+	logs, err := client.GetLoggedTime(context.Background(), LoggedTimeQuery{FromDays: 1, Reporter: progress.NewSilentReporter()})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), worklogRequests.Load())
+	assert.Len(t, logs.Days, 1)
+	assert.Equal(t, 27*time.Minute, logs.Days[0].TimeLogged)
+}
+
+func TestJiraWorklogs_IsComplete(t *testing.T) {
+	twoWorklogs := []JiraIssueWorklog{{ID: "1"}, {ID: "2"}}
+	tests := []struct {
+		name     string
+		worklogs JiraWorklogs
+		expected bool
+	}{
+		{name: "FieldNotRequested", worklogs: JiraWorklogs{}, expected: false},
+		{name: "NoWorklogs", worklogs: JiraWorklogs{MaxResults: 20, Worklogs: []JiraIssueWorklog{}}, expected: true},
+		{name: "AllEmbedded", worklogs: JiraWorklogs{MaxResults: 20, Total: 2, Worklogs: twoWorklogs}, expected: true},
+		{name: "Truncated", worklogs: JiraWorklogs{MaxResults: 2, Total: 30, Worklogs: twoWorklogs}, expected: false},
+		{name: "FewerThanTotal", worklogs: JiraWorklogs{MaxResults: 20, Total: 3, Worklogs: twoWorklogs}, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.worklogs.isComplete())
+		})
+	}
+}
+
+func TestGetAllWorklogs_StartedAfterInMilliseconds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startedAfter, err := strconv.ParseInt(r.URL.Query().Get("startedAfter"), 10, 64)
+		assert.NoError(t, err)
+		assert.InDelta(t, time.Now().Add(-24*time.Hour).UnixMilli(), startedAfter, float64(time.Minute.Milliseconds()))
+		w.Write([]byte(`{"worklogs": []}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+		ServerInfo: cachedServerInfo(configuration.FlavourServer),
+	}), nil)
+	_, err := client.getAllWorklogs(context.Background(), "TEST-1", 24*time.Hour)
+	assert.NoError(t, err)
+}
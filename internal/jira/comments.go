@@ -0,0 +1,38 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type commentRequest struct {
+	// Body is a plain string for REST API v2 and ADF document for v3.
+	Body       any         `json:"body"`
+	Visibility *Visibility `json:"visibility,omitempty"`
+}
+
+// AddComment posts comment on task and returns its ID. Nil visibility makes
+// comment visible to everyone who can see the task.
+func (c *JiraClient) AddComment(ctx context.Context, taskKey, text string, visibility *Visibility) (string, error) {
+	jsonData, err := json.Marshal(commentRequest{Body: c.richText(text), Visibility: visibility})
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.callPost(ctx, c.apiPath(fmt.Sprintf("/issue/%s/comment", taskKey)), jsonData, retryRejectedOnly, c.assertConfigurationIsValid)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to add comment: %w", c.newAPIError(resp))
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	// comment is posted already, missing ID isn't worth failing for
+	json.NewDecoder(resp.Body).Decode(&created)
+	return created.ID, nil
+}
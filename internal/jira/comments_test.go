@@ -0,0 +1,67 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddComment(t *testing.T) {
+	tests := []struct {
+		name         string
+		flavour      string
+		path         string
+		visibility   *Visibility
+		expectedBody map[string]any
+	}{
+		{
+			name:       "Server",
+			flavour:    configuration.FlavourServer,
+			path:       "/rest/api/2/issue/TEST-1/comment",
+			visibility: &Visibility{Type: VisibilityGroup, Value: "security"},
+			expectedBody: map[string]any{
+				"body":       "Fixed in 1.2",
+				"visibility": map[string]any{"type": "group", "value": "security"},
+			},
+		},
+		{
+			name:    "Cloud",
+			flavour: configuration.FlavourCloud,
+			path:    "/rest/api/3/issue/TEST-1/comment",
+			expectedBody: map[string]any{
+				"body": map[string]any{"type": "doc", "version": float64(1), "content": []any{
+					map[string]any{"type": "paragraph", "content": []any{map[string]any{"type": "text", "text": "Fixed in 1.2"}}},
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.path, r.URL.Path)
+				var body map[string]any
+				json.NewDecoder(r.Body).Decode(&body)
+				assert.Equal(t, tt.expectedBody, body)
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id": "10500"}`))
+			}))
+			defer server.Close()
+
+			client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+				JiraOrigin:  server.URL,
+				JiraToken:   "token123",
+				JiraEmail:   "me@example.com",
+				JiraFlavour: tt.flavour,
+			}), nil)
+			id, err := client.AddComment(context.Background(), "TEST-1", "Fixed in 1.2", tt.visibility)
+			assert.NoError(t, err)
+			assert.Equal(t, "10500", id)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Page size asked for when reading create metadata.
+const createMetaPageSize = 50
+
+// IssueType is a type of issue which can be created in a project, together
+// with fields of its create screen.
+type IssueType struct {
+	ID      string               `json:"id"`
+	Name    string               `json:"name"`
+	Subtask bool                 `json:"subtask"`
+	Fields  map[string]FieldMeta `json:"-"`
+}
+
+// NewIssue holds what's needed to create an issue. Fields holds values of any
+// other fields, keyed by field ID.
+type NewIssue struct {
+	ProjectKey  string
+	IssueTypeID string
+	ParentKey   string
+	Summary     string
+	Description string
+	Fields      map[string]any
+}
+
+type createMetaField struct {
+	FieldID string `json:"fieldId"`
+	FieldMeta
+}
+
+// createMetaPage is a page of createmeta response. Server returns entries in
+// values, Cloud in issueTypes or fields.
+type createMetaPage[T any] struct {
+	StartAt    int `json:"startAt"`
+	MaxResults int `json:"maxResults"`
+	Total      int `json:"total"`
+	Values     []T `json:"values"`
+	IssueTypes []T `json:"issueTypes"`
+	Fields     []T `json:"fields"`
+}
+
+func (p createMetaPage[T]) entries() []T {
+	return append(append(append([]T{}, p.Values...), p.IssueTypes...), p.Fields...)
+}
+
+type createIssueResponse struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// GetIssueType finds issue type of project by name, case insensitive, and
+// reads fields of its create screen. Empty name picks first sub-task type
+// when subtask is set.
+func (c *JiraClient) GetIssueType(ctx context.Context, projectKey, name string, subtask bool) (IssueType, error) {
+	issueTypes, err := readCreateMeta[IssueType](ctx, c, fmt.Sprintf("/issue/createmeta/%s/issuetypes", url.PathEscape(projectKey)))
+	if err != nil {
+		return IssueType{}, err
+	}
+	index := -1
+	for i, issueType := range issueTypes {
+		if (name == "" && issueType.Subtask == subtask) || (name != "" && strings.EqualFold(issueType.Name, name)) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		if name == "" {
+			name = "sub-task"
+		}
+		names := []string{}
+		for _, issueType := range issueTypes {
+			names = append(names, issueType.Name)
+		}
+		return IssueType{}, fmt.Errorf("%w %q in project %s, available: %s", errorUnknownIssueType, name, projectKey, strings.Join(names, ", "))
+	}
+	issueType := issueTypes[index]
+	fields, err := readCreateMeta[createMetaField](ctx, c, fmt.Sprintf("/issue/createmeta/%s/issuetypes/%s", url.PathEscape(projectKey), issueType.ID))
+	if err != nil {
+		return IssueType{}, err
+	}
+	issueType.Fields = map[string]FieldMeta{}
+	for _, field := range fields {
+		issueType.Fields[field.FieldID] = field.FieldMeta
+	}
+	return issueType, nil
+}
+
+func readCreateMeta[T any](ctx context.Context, c *JiraClient, path string) ([]T, error) {
+	entries := []T{}
+	for startAt := 0; ; {
+		resp, err := c.callGet(ctx, c.apiPath(fmt.Sprintf("%s?startAt=%d&maxResults=%d", path, startAt, createMetaPageSize)))
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := c.newAPIError(resp)
+			resp.Body.Close()
+			return nil, err
+		}
+		var page createMetaPage[T]
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		pageEntries := page.entries()
+		entries = append(entries, pageEntries...)
+		startAt += len(pageEntries)
+		if len(pageEntries) == 0 || startAt >= page.Total {
+			return entries, nil
+		}
+	}
+}
+
+// CreateIssue creates issue and returns its key.
+func (c *JiraClient) CreateIssue(ctx context.Context, issue NewIssue) (string, error) {
+	fields := map[string]any{}
+	for key, value := range issue.Fields {
+		fields[key] = value
+	}
+	fields["project"] = map[string]string{"key": issue.ProjectKey}
+	fields["issuetype"] = map[string]string{"id": issue.IssueTypeID}
+	fields["summary"] = issue.Summary
+	if issue.ParentKey != "" {
+		fields["parent"] = map[string]string{"key": issue.ParentKey}
+	}
+	if issue.Description != "" {
+		fields["description"] = c.richText(issue.Description)
+	}
+	jsonData, err := json.Marshal(map[string]any{"fields": fields})
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.callPost(ctx, c.apiPath("/issue"), jsonData, retryRejectedOnly, c.assertConfigurationIsValid)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to create issue: %w", c.newAPIError(resp))
+	}
+	var created createIssueResponse
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", err
+	}
+	return created.Key, nil
+}
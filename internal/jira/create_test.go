@@ -0,0 +1,116 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetIssueType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/issue/createmeta/ABC/issuetypes":
+			// types come in two pages to check paging
+			if r.URL.Query().Get("startAt") == "0" {
+				w.Write([]byte(`{"startAt": 0, "total": 3, "values": [{"id": "1", "name": "Task"}, {"id": "2", "name": "Bug"}]}`))
+				return
+			}
+			assert.Equal(t, "2", r.URL.Query().Get("startAt"))
+			w.Write([]byte(`{"startAt": 2, "total": 3, "values": [{"id": "5", "name": "Sub-task", "subtask": true}]}`))
+		case "/rest/api/2/issue/createmeta/ABC/issuetypes/5":
+			w.Write([]byte(`{"startAt": 0, "total": 2, "values": [
+				{"fieldId": "summary", "name": "Summary", "required": true},
+				{"fieldId": "components", "name": "Component", "required": true, "schema": {"type": "array", "items": "component"},
+				 "allowedValues": [{"id": "10", "name": "Backend"}]}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	}), nil)
+
+	issueType, err := client.GetIssueType(context.Background(), "ABC", "", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "5", issueType.ID)
+	assert.Equal(t, "Sub-task", issueType.Name)
+	assert.True(t, issueType.Fields["summary"].Required)
+	assert.Equal(t, "array", issueType.Fields["components"].Schema.Type)
+	assert.Equal(t, "Backend", issueType.Fields["components"].AllowedValues[0].Label())
+
+	_, err = client.GetIssueType(context.Background(), "ABC", "Story", false)
+	assert.ErrorIs(t, err, errorUnknownIssueType)
+	assert.ErrorContains(t, err, "available: Task, Bug, Sub-task")
+}
+
+func TestCreateIssue(t *testing.T) {
+	tests := []struct {
+		name                string
+		flavour             string
+		path                string
+		expectedDescription any
+	}{
+		{
+			name:                "Server",
+			flavour:             configuration.FlavourServer,
+			path:                "/rest/api/2/issue",
+			expectedDescription: "Found during review",
+		},
+		{
+			name:    "Cloud",
+			flavour: configuration.FlavourCloud,
+			path:    "/rest/api/3/issue",
+			expectedDescription: map[string]any{"type": "doc", "version": float64(1), "content": []any{
+				map[string]any{"type": "paragraph", "content": []any{map[string]any{"type": "text", "text": "Found during review"}}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, tt.path, r.URL.Path)
+				var body map[string]map[string]any
+				json.NewDecoder(r.Body).Decode(&body)
+				assert.Equal(t, map[string]any{
+					"project":     map[string]any{"key": "ABC"},
+					"issuetype":   map[string]any{"id": "5"},
+					"parent":      map[string]any{"key": "ABC-12"},
+					"summary":     "Fix flaky test",
+					"description": tt.expectedDescription,
+					"components":  []any{map[string]any{"id": "10"}},
+				}, body["fields"])
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id": "10042", "key": "ABC-13"}`))
+			}))
+			defer server.Close()
+
+			client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+				JiraOrigin:  server.URL,
+				JiraToken:   "token123",
+				JiraEmail:   "me@example.com",
+				JiraFlavour: tt.flavour,
+			}), nil)
+			key, err := client.CreateIssue(context.Background(), NewIssue{
+				ProjectKey:  "ABC",
+				IssueTypeID: "5",
+				ParentKey:   "ABC-12",
+				Summary:     "Fix flaky test",
+				Description: "Found during review",
+				Fields:      map[string]any{"components": []any{map[string]string{"id": "10"}}},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "ABC-13", key)
+		})
+	}
+}
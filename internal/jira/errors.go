@@ -1,11 +1,138 @@
 package jira
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
 
 var errorTokenNotConfigured = errors.New("before trying to connect to Jira configure Jira token")
 var errorOriginNotConfigured = errors.New("before trying to connect to Jira configure Jira origin")
 var errorFetchingAssignedIssues = errors.New("failed to fetch assigned issues")
+var errorFetchingWorklogs = errors.New("failed to fetch worklogs")
+var errorIdentifyingUser = errors.New("failed to identify current Jira user")
 var errorFailedToReadBody = errors.New("failed to read response body")
 var errorNoProtocolInOrigin = errors.New("jira origin is not valid. Set proper protocol schema")
 var errorEmailNotConfigured = errors.New("before trying this operation configure Jira email")
 var errorTokenEnvNameSetButEmpty = errors.New("env token name is configured but it's not set properly in Your system")
+var errorWorklogNotFound = errors.New("worklog not found")
+var errorTempoRequiresServer = errors.New("Tempo backend supports only Jira Server and Data Center")
+var errorVisibilityNotSupportedByTempo = errors.New("Tempo backend doesn't support worklog visibility")
+var errorAdjustEstimateNotSupportedByTempo = errors.New("Tempo backend supports only automatic estimate adjustment")
+var errorUnknownWorkAttribute = errors.New("unknown Tempo work attribute")
+var errorAttributesRequireTempo = errors.New("work attributes are supported only by Tempo backend, switch it with `logit config set-backend tempo`")
+var errorInvalidVisibility = errors.New("visibility must be in format role:name or group:name")
+var errorInvalidAdjustEstimate = errors.New("adjust estimate must be one of auto, leave, new or manual")
+var errorNewEstimateRequired = errors.New("new estimate is required when adjusting estimate to a new value")
+var errorEstimateAmountRequired = errors.New("amount to adjust estimate by is required in manual mode")
+var errorUnknownIssueType = errors.New("unknown issue type")
+var errorFilterNotFound = errors.New("no filter named")
+var errorAuthModeNotSupportedByCloud = errors.New("Jira Cloud supports only token authentication, switch back with `logit config set-auth token`")
+var errorUsernameNotConfigured = errors.New("basic auth requires user name, set it with `logit config set-username`")
+var errorOAuthNotConfigured = errors.New("OAuth is not configured, run `logit config init` to authorize logit")
+var errorInsecureHTTP = errors.New("refusing to send token over plain http, use https origin or allow http with `logit config set-connection --allow-http`")
+var errorInsecureSkipVerifyOnCloud = errors.New("skipping certificate verification is not allowed for Jira Cloud")
+var errorInvalidProxy = errors.New("proxy must be http, https or socks5 URL, got")
+var errorLoadingCABundle = errors.New("failed to load CA bundle")
+var errorIncompleteClientCert = errors.New("client certificate requires both certificate and key file")
+var errorLoadingClientCert = errors.New("failed to load client certificate")
+var errorTooManyWorklogChanges = errors.New("too many worklogs changed in Jira to sync them")
+var errorUnexpectedEstimateValue = errors.New("estimate value doesn't match chosen adjust estimate mode")
+
+// APIError describes unsuccessful response from Jira REST API.
+type APIError struct {
+	StatusCode    int
+	Method        string
+	Endpoint      string
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+	// Body holds raw response body when it couldn't be parsed as Jira error.
+	Body string `json:"-"`
+	// AuthMode is authentication mode request was sent with.
+	AuthMode string `json:"-"`
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Endpoint = resp.Request.URL.Path
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if err := json.Unmarshal(body, apiErr); err != nil || len(apiErr.Messages()) == 0 {
+		apiErr.Body = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("jira responded with %d %s to %s %s", e.StatusCode, http.StatusText(e.StatusCode), e.Method, e.Endpoint)
+	details := e.Messages()
+	if len(details) == 0 && e.Body != "" {
+		details = []string{e.Body}
+	}
+	if len(details) > 0 {
+		msg += ": " + strings.Join(details, "; ")
+	}
+	return msg
+}
+
+// Messages returns errorMessages followed by field errors in "field: message" form.
+func (e *APIError) Messages() []string {
+	messages := append([]string{}, e.ErrorMessages...)
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, fmt.Sprintf("%s: %s", field, e.Errors[field]))
+	}
+	return messages
+}
+
+// PartialResultError is returned together with incomplete Logs when worklogs
+// of some tasks could not be fetched, e.g. because the operation was interrupted.
+type PartialResultError struct {
+	Total  int
+	Failed map[string]error
+	Err    error
+}
+
+func (e *PartialResultError) Error() string {
+	reason := "some requests failed"
+	if e.Err != nil {
+		reason = e.Err.Error()
+	}
+	return fmt.Sprintf("fetched worklogs for %d/%d tasks (%s)", e.Fetched(), e.Total, reason)
+}
+
+func (e *PartialResultError) Unwrap() error {
+	return e.Err
+}
+
+func (e *PartialResultError) Fetched() int {
+	return e.Total - len(e.Failed)
+}
+
+// FailedKeys returns sorted keys of tasks which worklogs are missing from the result.
+func (e *PartialResultError) FailedKeys() []string {
+	keys := make([]string, 0, len(e.Failed))
+	for key := range e.Failed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (e *PartialResultError) Diagnostics() string {
+	lines := []string{}
+	for _, key := range e.FailedKeys() {
+		lines = append(lines, fmt.Sprintf("  %s: %v", key, e.Failed[key]))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,67 @@
+package jira
+
+import (
+	"net/url"
+)
+
+// Ways Jira can adjust remaining estimate of an issue when work is logged or deleted.
+const (
+	AdjustEstimateAuto   = "auto"
+	AdjustEstimateLeave  = "leave"
+	AdjustEstimateNew    = "new"
+	AdjustEstimateManual = "manual"
+)
+
+// EstimateAdjustment tells Jira what to do with remaining estimate. Zero value
+// keeps Jira default which is auto.
+type EstimateAdjustment struct {
+	Mode string
+	// NewEstimate is used in new mode, e.g. "2d 4h".
+	NewEstimate string
+	// Amount is used in manual mode. It's subtracted from the estimate when
+	// work is logged and added back when work is deleted.
+	Amount string
+}
+
+func (a EstimateAdjustment) Validate() error {
+	switch a.Mode {
+	case "", AdjustEstimateAuto, AdjustEstimateLeave:
+		if a.NewEstimate != "" || a.Amount != "" {
+			return errorUnexpectedEstimateValue
+		}
+	case AdjustEstimateNew:
+		if a.NewEstimate == "" {
+			return errorNewEstimateRequired
+		}
+		if a.Amount != "" {
+			return errorUnexpectedEstimateValue
+		}
+	case AdjustEstimateManual:
+		if a.Amount == "" {
+			return errorEstimateAmountRequired
+		}
+		if a.NewEstimate != "" {
+			return errorUnexpectedEstimateValue
+		}
+	default:
+		return errorInvalidAdjustEstimate
+	}
+	return nil
+}
+
+// query encodes adjustment as query string, amountParam names parameter
+// carrying Amount which differs between endpoints.
+func (a EstimateAdjustment) query(amountParam string) string {
+	if a.Mode == "" {
+		return ""
+	}
+	values := url.Values{}
+	values.Set("adjustEstimate", a.Mode)
+	switch a.Mode {
+	case AdjustEstimateNew:
+		values.Set("newEstimate", a.NewEstimate)
+	case AdjustEstimateManual:
+		values.Set(amountParam, a.Amount)
+	}
+	return "?" + values.Encode()
+}
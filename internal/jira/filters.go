@@ -0,0 +1,73 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Filter is a JQL query saved in Jira.
+type Filter struct {
+	ID    string     `json:"id"`
+	Name  string     `json:"name"`
+	JQL   string     `json:"jql"`
+	Owner JiraAuthor `json:"owner"`
+}
+
+// GetFilter reads filter by its ID or, when idOrName isn't a number, finds
+// one among favourite filters by name, case insensitive.
+func (c *JiraClient) GetFilter(ctx context.Context, idOrName string) (Filter, error) {
+	if _, err := strconv.Atoi(idOrName); err == nil {
+		return c.getFilterByID(ctx, idOrName)
+	}
+	filters, err := c.GetFavouriteFilters(ctx)
+	if err != nil {
+		return Filter{}, err
+	}
+	names := []string{}
+	for _, filter := range filters {
+		if strings.EqualFold(filter.Name, idOrName) {
+			return filter, nil
+		}
+		names = append(names, filter.Name)
+	}
+	return Filter{}, fmt.Errorf("%w %q among favourite filters: %s", errorFilterNotFound, idOrName, strings.Join(names, ", "))
+}
+
+func (c *JiraClient) getFilterByID(ctx context.Context, id string) (Filter, error) {
+	resp, err := c.callGet(ctx, c.apiPath("/filter/"+id))
+	if err != nil {
+		return Filter{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Filter{}, c.newAPIError(resp)
+	}
+	var filter Filter
+	if err := json.NewDecoder(resp.Body).Decode(&filter); err != nil {
+		return Filter{}, err
+	}
+	return filter, nil
+}
+
+// GetFavouriteFilters lists filters current user marked as favourite.
+func (c *JiraClient) GetFavouriteFilters(ctx context.Context) ([]Filter, error) {
+	resp, err := c.callGet(ctx, c.apiPath("/filter/favourite"))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.newAPIError(resp)
+	}
+	var filters []Filter
+	if err := json.NewDecoder(resp.Body).Decode(&filters); err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
@@ -0,0 +1,96 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/filter/10001":
+			w.Write([]byte(`{"id": "10001", "name": "Team backlog", "jql": "project = ABC AND resolution = Unresolved"}`))
+		case "/rest/api/2/filter/favourite":
+			w.Write([]byte(`[
+				{"id": "10001", "name": "Team backlog", "jql": "project = ABC AND resolution = Unresolved"},
+				{"id": "10002", "name": "My bugs", "jql": "assignee = currentUser() AND type = Bug"}]`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	}), nil)
+
+	tests := []struct {
+		idOrName      string
+		expectedID    string
+		expectedError error
+	}{
+		{idOrName: "10001", expectedID: "10001"},
+		{idOrName: "my BUGS", expectedID: "10002"},
+		{idOrName: "Sprint board", expectedError: errorFilterNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.idOrName, func(t *testing.T) {
+			filter, err := client.GetFilter(context.Background(), tt.idOrName)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.ErrorContains(t, err, "Team backlog, My bugs")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedID, filter.ID)
+			assert.NotEmpty(t, filter.JQL)
+		})
+	}
+}
+
+func TestGetAssignedIssues_TasksJQL(t *testing.T) {
+	tests := []struct {
+		name        string
+		tasksJQL    string
+		expectedJQL string
+	}{
+		{
+			name:        "Default",
+			expectedJQL: configuration.DefaultTasksJQL,
+		},
+		{
+			name:        "Configured",
+			tasksJQL:    "assignee = currentUser() AND status not in (Resolved, Released)",
+			expectedJQL: "assignee = currentUser() AND status not in (Resolved, Released)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var query SearchJql
+				json.NewDecoder(r.Body).Decode(&query)
+				assert.Equal(t, tt.expectedJQL, query.JQL)
+				w.Write([]byte(`{"issues": [], "total": 0}`))
+			}))
+			defer server.Close()
+
+			client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+				JiraOrigin: server.URL,
+				JiraToken:  "token123",
+				TasksJQL:   tt.tasksJQL,
+				ServerInfo: cachedServerInfo(configuration.FlavourServer),
+			}), nil)
+			issues, err := client.GetAssignedIssues(context.Background())
+			assert.NoError(t, err)
+			assert.Empty(t, issues)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Names of custom fields holding sprint and epic, their IDs differ between instances.
+const (
+	sprintFieldName   = "Sprint"
+	epicLinkFieldName = "Epic Link"
+)
+
+// Older Jira Server describes sprint as string like
+// "com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=1,state=ACTIVE,name=Sprint 4,...]".
+var legacySprintNamePattern = regexp.MustCompile(`name=([^,\]]*)`)
+
+// IssueDetails is a detailed view of a single issue.
+type IssueDetails struct {
+	Key          string         `json:"key"`
+	Summary      string         `json:"summary"`
+	Type         string         `json:"type"`
+	Status       string         `json:"status"`
+	Priority     string         `json:"priority,omitempty"`
+	Assignee     string         `json:"assignee,omitempty"`
+	Reporter     string         `json:"reporter,omitempty"`
+	Sprints      []string       `json:"sprints,omitempty"`
+	Parent       *IssueRef      `json:"parent,omitempty"`
+	Epic         string         `json:"epic,omitempty"`
+	TimeTracking TimeTracking   `json:"timeTracking"`
+	WorklogTotal int            `json:"worklogTotal"`
+	Worklogs     []IssueWorklog `json:"worklogs"`
+}
+
+// IssueRef points to another issue, e.g. parent.
+type IssueRef struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Type    string `json:"type"`
+}
+
+// IssueWorklog is a worklog of any author.
+type IssueWorklog struct {
+	ID        string        `json:"id"`
+	Author    string        `json:"author"`
+	Started   time.Time     `json:"started"`
+	TimeSpent time.Duration `json:"-"`
+	Comment   string        `json:"comment,omitempty"`
+}
+
+// MarshalJSON writes time spent in seconds, as Jira does.
+func (w IssueWorklog) MarshalJSON() ([]byte, error) {
+	type plain IssueWorklog
+	return json.Marshal(struct {
+		plain
+		TimeSpent int `json:"timeSpentSeconds"`
+	}{plain: plain(w), TimeSpent: int(w.TimeSpent.Seconds())})
+}
+
+type namedValue struct {
+	Name string `json:"name"`
+}
+
+type issueDetailsResponse struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary   string     `json:"summary"`
+		Status    namedValue `json:"status"`
+		IssueType namedValue `json:"issuetype"`
+		Priority  namedValue `json:"priority"`
+		Assignee  JiraAuthor `json:"assignee"`
+		Reporter  JiraAuthor `json:"reporter"`
+		Parent    *struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Summary   string     `json:"summary"`
+				IssueType namedValue `json:"issuetype"`
+			} `json:"fields"`
+		} `json:"parent"`
+		TimeTracking TimeTracking `json:"timetracking"`
+	} `json:"fields"`
+	// Names maps field IDs to their names, it's how custom fields are found.
+	Names map[string]string `json:"names"`
+}
+
+// GetIssueDetails fetches issue together with its last worklogCount worklogs.
+func (c *JiraClient) GetIssueDetails(ctx context.Context, taskKey string, worklogCount int) (IssueDetails, error) {
+	resp, err := c.callGet(ctx, c.apiPath(fmt.Sprintf("/issue/%s?expand=names&fields=*navigable,-comment,-worklog,-description", taskKey)))
+	if err != nil {
+		return IssueDetails{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return IssueDetails{}, c.newAPIError(resp)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return IssueDetails{}, fmt.Errorf("%w: %w", errorFailedToReadBody, err)
+	}
+	details, err := parseIssueDetails(body)
+	if err != nil {
+		return IssueDetails{}, err
+	}
+	if worklogCount <= 0 {
+		return details, nil
+	}
+
+	worklogs, err := c.getAllWorklogs(ctx, details.Key, 0)
+	if err != nil {
+		return IssueDetails{}, fmt.Errorf("%w: %w", errorFetchingWorklogs, err)
+	}
+	details.WorklogTotal = len(worklogs)
+	for _, log := range worklogs {
+		started, err := time.Parse(jiraTimeFormat, log.Started)
+		if err != nil {
+			continue
+		}
+		details.Worklogs = append(details.Worklogs, IssueWorklog{
+			ID:        log.ID,
+			Author:    log.Author.DisplayName,
+			Started:   started,
+			TimeSpent: time.Duration(log.TimeSpentSeconds) * time.Second,
+			Comment:   string(log.Comment),
+		})
+	}
+	slices.SortStableFunc(details.Worklogs, func(a, b IssueWorklog) int {
+		return a.Started.Compare(b.Started)
+	})
+	if len(details.Worklogs) > worklogCount {
+		details.Worklogs = details.Worklogs[len(details.Worklogs)-worklogCount:]
+	}
+	return details, nil
+}
+
+func parseIssueDetails(body []byte) (IssueDetails, error) {
+	var issue issueDetailsResponse
+	if err := json.Unmarshal(body, &issue); err != nil {
+		return IssueDetails{}, err
+	}
+	var raw struct {
+		Fields map[string]json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return IssueDetails{}, err
+	}
+	fields := issue.Fields
+	details := IssueDetails{
+		Key:          issue.Key,
+		Summary:      fields.Summary,
+		Type:         fields.IssueType.Name,
+		Status:       fields.Status.Name,
+		Priority:     fields.Priority.Name,
+		Assignee:     fields.Assignee.DisplayName,
+		Reporter:     fields.Reporter.DisplayName,
+		TimeTracking: fields.TimeTracking,
+		Worklogs:     []IssueWorklog{},
+	}
+	if fields.Parent != nil {
+		details.Parent = &IssueRef{
+			Key:     fields.Parent.Key,
+			Summary: fields.Parent.Fields.Summary,
+			Type:    fields.Parent.Fields.IssueType.Name,
+		}
+	}
+	for id, name := range issue.Names {
+		value, ok := raw.Fields[id]
+		if !ok {
+			continue
+		}
+		switch name {
+		case sprintFieldName:
+			details.Sprints = parseSprints(value)
+		case epicLinkFieldName:
+			json.Unmarshal(value, &details.Epic)
+		}
+	}
+	return details, nil
+}
+
+func parseSprints(value json.RawMessage) []string {
+	var sprints []namedValue
+	if err := json.Unmarshal(value, &sprints); err == nil {
+		names := []string{}
+		for _, sprint := range sprints {
+			names = append(names, sprint.Name)
+		}
+		return names
+	}
+	var legacy []string
+	if err := json.Unmarshal(value, &legacy); err != nil {
+		return nil
+	}
+	names := []string{}
+	for _, sprint := range legacy {
+		if match := legacySprintNamePattern.FindStringSubmatch(sprint); match != nil {
+			names = append(names, strings.TrimSpace(match[1]))
+		}
+	}
+	return names
+}
@@ -0,0 +1,79 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetIssueDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/issue/ABC-12":
+			assert.Equal(t, "names", r.URL.Query().Get("expand"))
+			w.Write([]byte(`{"key": "ABC-12", "fields": {
+				"summary": "Fix login", "status": {"name": "In Progress"}, "issuetype": {"name": "Bug"},
+				"priority": {"name": "High"}, "assignee": {"displayName": "Jane Doe"}, "reporter": {"displayName": "John Roe"},
+				"timetracking": {"originalEstimate": "1d", "remainingEstimate": "4h", "timeSpent": "4h"},
+				"customfield_10100": ["com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=7,rapidViewId=1,state=ACTIVE,name=Sprint 4,startDate=2024-03-01]"],
+				"customfield_10200": "ABC-1"},
+				"names": {"summary": "Summary", "customfield_10100": "Sprint", "customfield_10200": "Epic Link"}}`))
+		case "/rest/api/2/issue/ABC-12/worklog":
+			assert.Empty(t, r.URL.Query().Get("startedAfter"))
+			w.Write([]byte(`{"worklogs": [
+				{"id": "3", "author": {"displayName": "Jane Doe"}, "started": "2024-03-05T09:00:00.000+0000", "timeSpentSeconds": 3600, "comment": "Debugging"},
+				{"id": "1", "author": {"displayName": "John Roe"}, "started": "2024-03-01T09:00:00.000+0000", "timeSpentSeconds": 1800},
+				{"id": "2", "author": {"displayName": "Jane Doe"}, "started": "2024-03-04T09:00:00.000+0000", "timeSpentSeconds": 9000}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	}), nil)
+	details, err := client.GetIssueDetails(context.Background(), "ABC-12", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "Fix login", details.Summary)
+	assert.Equal(t, "Bug", details.Type)
+	assert.Equal(t, "High", details.Priority)
+	assert.Equal(t, "Jane Doe", details.Assignee)
+	assert.Equal(t, "John Roe", details.Reporter)
+	assert.Equal(t, []string{"Sprint 4"}, details.Sprints)
+	assert.Equal(t, "ABC-1", details.Epic)
+	assert.Equal(t, "4h", details.TimeTracking.RemainingEstimate)
+	assert.Equal(t, 3, details.WorklogTotal)
+	assert.Len(t, details.Worklogs, 2)
+	assert.Equal(t, "2", details.Worklogs[0].ID)
+	assert.Equal(t, "3", details.Worklogs[1].ID)
+	assert.Equal(t, time.Hour, details.Worklogs[1].TimeSpent)
+}
+
+func TestParseIssueDetails_Cloud(t *testing.T) {
+	body := []byte(`{"key": "ABC-13", "fields": {
+		"summary": "Write docs", "status": {"name": "To Do"}, "issuetype": {"name": "Task"}, "assignee": null,
+		"parent": {"key": "ABC-1", "fields": {"summary": "Onboarding", "issuetype": {"name": "Epic"}}},
+		"customfield_10020": [{"id": 7, "name": "Sprint 4", "state": "closed"}, {"id": 8, "name": "Sprint 5", "state": "active"}]},
+		"names": {"customfield_10020": "Sprint"}}`)
+
+	details, err := parseIssueDetails(body)
+	assert.NoError(t, err)
+	assert.Empty(t, details.Assignee)
+	assert.Equal(t, []string{"Sprint 4", "Sprint 5"}, details.Sprints)
+	assert.Equal(t, &IssueRef{Key: "ABC-1", Summary: "Onboarding", Type: "Epic"}, details.Parent)
+}
+
+func TestIssueWorklog_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(IssueWorklog{ID: "1", Author: "Jane Doe", Started: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), TimeSpent: 90 * time.Minute})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id": "1", "author": "Jane Doe", "started": "2024-03-01T09:00:00Z", "timeSpentSeconds": 5400}`, string(data))
+}
@@ -1,17 +1,73 @@
 package jira
 
 import (
+	"context"
 	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/progress"
 )
 
 type Client interface {
-	LogTime(taskKey string, duration time.Duration, started time.Time, comment string) error
-	GetAssignedIssues() ([]Issue, error)
-	GetLoggedTime(fromDays int) (Logs, error)
+	SprintClient
+	LogTime(ctx context.Context, taskKey string, duration time.Duration, started time.Time, comment string, options LogTimeOptions) (string, error)
+	GetTimeTracking(ctx context.Context, taskKey string) (TimeTracking, error)
+	GetAssignedIssues(ctx context.Context) ([]Issue, error)
+	SearchIssues(ctx context.Context, jql string) ([]Issue, error)
+	GetFilter(ctx context.Context, idOrName string) (Filter, error)
+	GetFavouriteFilters(ctx context.Context) ([]Filter, error)
+	GetLoggedTime(ctx context.Context, query LoggedTimeQuery) (Logs, error)
+	GetCurrentUser(ctx context.Context, refresh bool) (configuration.JiraUser, error)
+	GetWorklog(ctx context.Context, worklogID string) (WorklogEntry, error)
+	GetWorklogsOn(ctx context.Context, day time.Time) ([]WorklogEntry, error)
+	UpdateWorklog(ctx context.Context, taskKey, worklogID string, update WorklogUpdate) error
+	DeleteWorklog(ctx context.Context, taskKey, worklogID string, adjustment EstimateAdjustment) error
+	GetTransitions(ctx context.Context, taskKey string) ([]Transition, error)
+	TransitionIssue(ctx context.Context, taskKey, transitionID string, fields map[string]any) error
+	AddComment(ctx context.Context, taskKey, text string, visibility *Visibility) (string, error)
+	GetIssueType(ctx context.Context, projectKey, name string, subtask bool) (IssueType, error)
+	CreateIssue(ctx context.Context, issue NewIssue) (string, error)
+	GetIssueDetails(ctx context.Context, taskKey string, worklogCount int) (IssueDetails, error)
+}
+
+// TimesheetClient manages approvals of Tempo timesheets.
+type TimesheetClient interface {
+	GetTimesheetApproval(ctx context.Context, day time.Time) (TimesheetApproval, error)
+	SubmitTimesheet(ctx context.Context, day time.Time, reviewerKey, comment string) (TimesheetApproval, error)
+	GetWorkedDays(ctx context.Context, from, to time.Time) (map[string]time.Duration, error)
+}
+
+// SprintClient reads sprints of Jira Software boards.
+type SprintClient interface {
+	GetSprints(ctx context.Context, boardID int, states ...string) ([]Sprint, error)
+	GetRecentSprints(ctx context.Context, boardID, count int, states ...string) ([]Sprint, error)
+	GetSprintIssues(ctx context.Context, sprintID int, jql string) ([]Issue, error)
+	GetSprintLoggedTime(ctx context.Context, sprint Sprint) (SprintLoggedTime, error)
+}
+
+// LogTimeOptions holds optional settings of logged work.
+type LogTimeOptions struct {
+	Adjustment EstimateAdjustment
+	// Visibility restricts who can see the worklog, nil means everyone who can see the issue.
+	Visibility *Visibility
+	// Attributes maps Tempo work attribute name or key to its value.
+	Attributes map[string]string
+}
+
+type LoggedTimeQuery struct {
+	FromDays int
+	// Refresh discards local worklog cache and fetches everything from Jira.
+	Refresh  bool
+	Reporter progress.Reporter
 }
 
 type Result struct {
-	Issues []JiraIssue `json:"issues"`
+	Issues        []JiraIssue `json:"issues"`
+	StartAt       int         `json:"startAt"`
+	MaxResults    int         `json:"maxResults"`
+	Total         int         `json:"total"`
+	NextPageToken string      `json:"nextPageToken"`
+	IsLast        bool        `json:"isLast"`
 }
 
 type Issue struct {
@@ -21,6 +77,7 @@ type Issue struct {
 }
 
 type JiraIssue struct {
+	ID     string          `json:"id"`
 	Key    string          `json:"key"`
 	Fields JiraIssueFields `json:"fields"`
 }
@@ -30,23 +87,49 @@ type JiraStatus struct {
 }
 
 type JiraIssueFields struct {
-	Worklog JiraWorklogs `json:"worklog"`
-	Summary string       `json:"summary"`
-	Status  JiraStatus   `json:"status"`
+	Worklog      JiraWorklogs `json:"worklog"`
+	Summary      string       `json:"summary"`
+	Status       JiraStatus   `json:"status"`
+	TimeTracking TimeTracking `json:"timetracking"`
+}
+
+// TimeTracking holds estimates of an issue in Jira duration format, e.g. "1d 2h".
+// Empty estimate means it was never set.
+type TimeTracking struct {
+	OriginalEstimate  string `json:"originalEstimate"`
+	RemainingEstimate string `json:"remainingEstimate"`
+	TimeSpent         string `json:"timeSpent"`
 }
 
 type JiraWorklogs struct {
-	Worklogs []JiraIssueWorklog `json:"worklogs"`
+	StartAt    int                `json:"startAt"`
+	MaxResults int                `json:"maxResults"`
+	Total      int                `json:"total"`
+	Worklogs   []JiraIssueWorklog `json:"worklogs"`
+}
+
+// isComplete tells whether worklogs embedded in search results are all
+// worklogs of the issue. Search embeds at most maxResults of them and leaves
+// maxResults zero when worklog field wasn't requested.
+func (w JiraWorklogs) isComplete() bool {
+	return w.MaxResults > 0 && w.Total <= w.MaxResults && len(w.Worklogs) == w.Total
 }
 
 type JiraIssueWorklog struct {
-	Author           JiraAuthor `json:"author"`
-	Started          string     `json:"started"`
-	TimeSpent        string     `json:"timeSpent"`
-	TimeSpentSeconds int        `json:"timeSpentSeconds"`
+	ID               string      `json:"id"`
+	IssueID          string      `json:"issueId"`
+	Author           JiraAuthor  `json:"author"`
+	Started          string      `json:"started"`
+	TimeSpent        string      `json:"timeSpent"`
+	TimeSpentSeconds int         `json:"timeSpentSeconds"`
+	Comment          RichText    `json:"comment"`
+	Visibility       *Visibility `json:"visibility"`
 }
 
 type JiraAuthor struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	AccountID   string `json:"accountId"`
 	DisplayName string `json:"displayName"`
 	Email       string `json:"emailAddress"`
 }
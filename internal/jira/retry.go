@@ -0,0 +1,144 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// retryMode tells which failures are safe to retry for a given request.
+type retryMode int
+
+const (
+	// retryIdempotent retries every transient failure. Use it for reads and
+	// for writes which can be repeated without side effects.
+	retryIdempotent retryMode = iota
+	// retryRejectedOnly retries only when Jira explicitly rejected the request
+	// without processing it (429, 503) or the connection was never made.
+	// Use it for requests like worklog creation where a blind retry could
+	// log the same time twice.
+	retryRejectedOnly
+)
+
+type retryPolicy struct {
+	maxAttempts   int
+	baseDelay     time.Duration
+	maxDelay      time.Duration
+	maxRetryAfter time.Duration
+	sleep         func(ctx context.Context, d time.Duration) error
+	now           func() time.Time
+}
+
+func defaultRetryPolicy() retryPolicy {
+	return retryPolicy{
+		maxAttempts:   4,
+		baseDelay:     500 * time.Millisecond,
+		maxDelay:      10 * time.Second,
+		maxRetryAfter: time.Minute,
+		sleep:         sleepContext,
+		now:           time.Now,
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// do sends request built by newRequest until it succeeds, fails permanently
+// or runs out of attempts. newRequest is called for every attempt so that
+// request body can be read again.
+func (p retryPolicy) do(ctx context.Context, client *http.Client, mode retryMode, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if attempt >= p.maxAttempts || !p.shouldRetry(mode, resp, err) {
+			return resp, err
+		}
+		delay, ok := p.delay(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := p.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p retryPolicy) shouldRetry(mode retryMode, resp *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return true
+		}
+		return mode == retryIdempotent && isConnectionReset(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return mode == retryIdempotent
+	}
+	return false
+}
+
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// delay returns how long to wait before next attempt. Server hints take
+// precedence over exponential backoff. If server asks to wait longer than
+// maxRetryAfter the second return value is false and retrying is abandoned.
+func (p retryPolicy) delay(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if d, ok := p.serverDelay(resp.Header); ok {
+			return d, d <= p.maxRetryAfter
+		}
+	}
+	backoff := p.baseDelay << (attempt - 1)
+	if backoff > p.maxDelay || backoff <= 0 {
+		backoff = p.maxDelay
+	}
+	return backoff/2 + rand.N(backoff/2+1), true
+}
+
+func (p retryPolicy) serverDelay(header http.Header) (time.Duration, bool) {
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return max(date.Sub(p.now()), 0), true
+		}
+	}
+	// Jira Cloud announces when the rate limit window resets.
+	if reset := header.Get("X-RateLimit-Reset"); reset != "" {
+		if date, err := time.Parse(time.RFC3339, reset); err == nil {
+			return max(date.Sub(p.now()), 0), true
+		}
+	}
+	// Jira Data Center uses token bucket and tells how often tokens are refilled.
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		if seconds, err := strconv.Atoi(header.Get("X-RateLimit-Interval-Seconds")); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,210 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRetryTestClient(origin string, delays *[]time.Duration) *JiraClient {
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: origin,
+		JiraToken:  "token123",
+		ServerInfo: cachedServerInfo(configuration.FlavourServer),
+	}), nil)
+	client.retry.sleep = func(ctx context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return nil
+	}
+	client.retry.now = func() time.Time {
+		return time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	}
+	return client
+}
+
+func TestRetry_RetriesTransientStatuses(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch attempts.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"issues": []}`))
+		}
+	}))
+	defer server.Close()
+
+	delays := []time.Duration{}
+	client := newRetryTestClient(server.URL, &delays)
+	_, err := client.GetAssignedIssues(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), attempts.Load())
+	assert.Len(t, delays, 2)
+	assert.GreaterOrEqual(t, delays[0], 250*time.Millisecond)
+	assert.LessOrEqual(t, delays[0], 500*time.Millisecond)
+	assert.GreaterOrEqual(t, delays[1], 500*time.Millisecond)
+	assert.LessOrEqual(t, delays[1], time.Second)
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	delays := []time.Duration{}
+	client := newRetryTestClient(server.URL, &delays)
+	_, err := client.GetAssignedIssues(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, int32(4), attempts.Load())
+}
+
+func TestRetry_RespectsServerHints(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        map[string]string
+		expectedDelay time.Duration
+	}{
+		{
+			name:          "RetryAfterSeconds",
+			header:        map[string]string{"Retry-After": "7"},
+			expectedDelay: 7 * time.Second,
+		},
+		{
+			name:          "RetryAfterDate",
+			header:        map[string]string{"Retry-After": "Sat, 17 Oct 2026 12:00:05 GMT"},
+			expectedDelay: 5 * time.Second,
+		},
+		{
+			name:          "RateLimitReset",
+			header:        map[string]string{"X-RateLimit-Reset": "2026-10-17T12:00:30Z"},
+			expectedDelay: 30 * time.Second,
+		},
+		{
+			name:          "RateLimitInterval",
+			header:        map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Interval-Seconds": "2"},
+			expectedDelay: 2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) == 1 {
+					for k, v := range tt.header {
+						w.Header().Set(k, v)
+					}
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.Write([]byte(`{"issues": []}`))
+			}))
+			defer server.Close()
+
+			delays := []time.Duration{}
+			client := newRetryTestClient(server.URL, &delays)
+			_, err := client.GetAssignedIssues(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, []time.Duration{tt.expectedDelay}, delays)
+		})
+	}
+}
+
+func TestRetry_DoesNotWaitLongerThanAllowed(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	delays := []time.Duration{}
+	client := newRetryTestClient(server.URL, &delays)
+	_, err := client.GetAssignedIssues(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), attempts.Load())
+	assert.Empty(t, delays)
+}
+
+func TestRetry_LogTimeIsNotRetriedOnAmbiguousFailure(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	delays := []time.Duration{}
+	client := newRetryTestClient(server.URL, &delays)
+	_, err := client.LogTime(context.Background(), "TEST-123", time.Hour, time.Now(), "", LogTimeOptions{})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestRetry_LogTimeIsRetriedWhenRateLimited(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	delays := []time.Duration{}
+	client := newRetryTestClient(server.URL, &delays)
+	_, err := client.LogTime(context.Background(), "TEST-123", time.Hour, time.Now(), "", LogTimeOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestRetry_RetriesConnectionReset(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Write([]byte(`{"issues": []}`))
+	}))
+	defer server.Close()
+
+	delays := []time.Duration{}
+	client := newRetryTestClient(server.URL, &delays)
+	_, err := client.GetAssignedIssues(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestRetry_StopsWhenContextIsCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	}), nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.GetAssignedIssues(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
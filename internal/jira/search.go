@@ -0,0 +1,120 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const searchPageSize = 100
+
+// searchIterator walks over all issues matching JQL query fetching consecutive
+// pages on demand. Jira Server pages /search with startAt/total while Jira
+// Cloud pages /search/jql with nextPageToken, iterator hides the difference.
+//
+//	it := c.newSearchIterator(jql, fields, c.assertConfigurationIsValid)
+//	for it.Next(ctx) {
+//		issue := it.Issue()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type searchIterator struct {
+	client   *JiraClient
+	query    SearchJql
+	validate func() error
+	page     []JiraIssue
+	index    int
+	lastPage bool
+	err      error
+}
+
+func (c *JiraClient) newSearchIterator(jql string, fields []string, validate func() error) *searchIterator {
+	return &searchIterator{
+		client: c,
+		query: SearchJql{
+			Fields:     fields,
+			JQL:        jql,
+			MaxResults: searchPageSize,
+		},
+		validate: validate,
+		index:    -1,
+	}
+}
+
+// Next advances iterator to the next issue, fetching next page when needed.
+// It returns false when there are no more issues or an error occurred.
+func (it *searchIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	for it.index >= len(it.page) {
+		if it.lastPage {
+			return false
+		}
+		if err := it.fetchPage(ctx); err != nil {
+			it.err = err
+			return false
+		}
+		it.index = 0
+	}
+	return true
+}
+
+func (it *searchIterator) Issue() JiraIssue {
+	return it.page[it.index]
+}
+
+func (it *searchIterator) Err() error {
+	return it.err
+}
+
+func (it *searchIterator) fetchPage(ctx context.Context) error {
+	jsonData, err := json.Marshal(it.query)
+	if err != nil {
+		return err
+	}
+	caps := it.client.getCapabilities(ctx)
+	resp, err := it.client.callPost(ctx, it.client.searchEndpoint(caps), jsonData, retryIdempotent, it.validate)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return it.client.newAPIError(resp)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errorFailedToReadBody
+	}
+	var result Result
+	if err := json.Unmarshal(body, &result); err != nil {
+		return err
+	}
+
+	it.page = result.Issues
+	if caps.searchJQL {
+		it.query.NextPageToken = result.NextPageToken
+		it.lastPage = result.IsLast || result.NextPageToken == ""
+	} else {
+		it.query.StartAt = result.StartAt + len(result.Issues)
+		it.lastPage = len(result.Issues) == 0 || it.query.StartAt >= result.Total
+	}
+	return nil
+}
+
+// collectIssues drains iterator into a slice.
+func collectIssues(ctx context.Context, it *searchIterator) ([]JiraIssue, error) {
+	issues := []JiraIssue{}
+	for it.Next(ctx) {
+		issues = append(issues, it.Issue())
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("search failed after %d issues: %w", len(issues), err)
+	}
+	return issues, nil
+}
@@ -0,0 +1,161 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/progress"
+	"github.com/stretchr/testify/assert"
+)
+
+func issuesPage(from, to int) []string {
+	issues := []string{}
+	for i := from; i < to; i++ {
+		issues = append(issues, fmt.Sprintf(`{"key": "ISSUE-%d", "fields": {"summary": "Issue %d", "status": {"name": "To Do"}}}`, i, i))
+	}
+	return issues
+}
+
+// newPagingServer serves total issues in pages of pageSize, ignoring requested
+// maxResults the same way Jira caps it.
+func newPagingServer(t *testing.T, total, pageSize int, requests *[]SearchJql) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var query SearchJql
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+		*requests = append(*requests, query)
+
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			to := min(query.StartAt+pageSize, total)
+			fmt.Fprintf(w, `{"startAt": %d, "maxResults": %d, "total": %d, "issues": [%s]}`, query.StartAt, pageSize, total, strings.Join(issuesPage(query.StartAt, to), ","))
+		case "/rest/api/3/search/jql":
+			from := 0
+			if query.NextPageToken != "" {
+				fmt.Sscanf(query.NextPageToken, "token-%d", &from)
+			}
+			to := min(from+pageSize, total)
+			if to == total {
+				fmt.Fprintf(w, `{"issues": [%s], "isLast": true}`, strings.Join(issuesPage(from, to), ","))
+				return
+			}
+			fmt.Fprintf(w, `{"issues": [%s], "nextPageToken": "token-%d", "isLast": false}`, strings.Join(issuesPage(from, to), ","), to)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestSearchIterator_Pagination(t *testing.T) {
+	tests := []struct {
+		name             string
+		flavour          string
+		total            int
+		pageSize         int
+		expectedRequests int
+	}{
+		{name: "ServerMultiplePages", flavour: configuration.FlavourServer, total: 250, pageSize: 100, expectedRequests: 3},
+		{name: "ServerExactPages", flavour: configuration.FlavourServer, total: 100, pageSize: 50, expectedRequests: 2},
+		{name: "ServerNoResults", flavour: configuration.FlavourServer, total: 0, pageSize: 50, expectedRequests: 1},
+		{name: "CloudMultiplePages", flavour: configuration.FlavourCloud, total: 250, pageSize: 100, expectedRequests: 3},
+		{name: "CloudSinglePage", flavour: configuration.FlavourCloud, total: 3, pageSize: 100, expectedRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := []SearchJql{}
+			server := newPagingServer(t, tt.total, tt.pageSize, &requests)
+			defer server.Close()
+
+			client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+				JiraOrigin:  server.URL,
+				JiraToken:   "token123",
+				JiraEmail:   "me@example.com",
+				JiraFlavour: tt.flavour,
+				ServerInfo:  cachedServerInfo(tt.flavour),
+			}), nil)
+			it := client.newSearchIterator("assignee = currentUser()", []string{"key"}, client.assertConfigurationIsValid)
+			issues, err := collectIssues(context.Background(), it)
+
+			assert.NoError(t, err)
+			assert.Len(t, issues, tt.total)
+			assert.Len(t, requests, tt.expectedRequests)
+			for i, issue := range issues {
+				assert.Equal(t, fmt.Sprintf("ISSUE-%d", i), issue.Key)
+			}
+		})
+	}
+}
+
+func TestSearchIterator_ErrorOnLaterPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var query SearchJql
+		json.NewDecoder(r.Body).Decode(&query)
+		if query.StartAt > 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorMessages": ["broken page"]}`))
+			return
+		}
+		fmt.Fprintf(w, `{"startAt": 0, "maxResults": 2, "total": 4, "issues": [%s]}`, strings.Join(issuesPage(0, 2), ","))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+		ServerInfo: cachedServerInfo(configuration.FlavourServer),
+	}), nil)
+	it := client.newSearchIterator("assignee = currentUser()", []string{"key"}, client.assertConfigurationIsValid)
+	count := 0
+	for it.Next(context.Background()) {
+		count++
+	}
+	assert.Equal(t, 2, count)
+	assert.ErrorContains(t, it.Err(), "broken page")
+	assert.False(t, it.Next(context.Background()))
+}
+
+func TestGetAssignedIssues_AllPages(t *testing.T) {
+	requests := []SearchJql{}
+	server := newPagingServer(t, 130, 50, &requests)
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+		ServerInfo: cachedServerInfo(configuration.FlavourServer),
+	}), nil)
+	issues, err := client.GetAssignedIssues(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, issues, 130)
+	assert.Equal(t, []int{0, 50, 100}, []int{requests[0].StartAt, requests[1].StartAt, requests[2].StartAt})
+}
+
+func TestGetLoggedTime_AllPages(t *testing.T) {
+	requests := []SearchJql{}
+	search := newPagingServer(t, 120, 100, &requests)
+	defer search.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/worklog") {
+			w.Write([]byte(`{"worklogs": []}`))
+			return
+		}
+		search.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+		JiraUser:   &configuration.JiraUser{Key: "me"},
+		ServerInfo: cachedServerInfo(configuration.FlavourServer),
+	}), nil)
+	_, err := client.GetLoggedTime(context.Background(), LoggedTimeQuery{FromDays: 7, Reporter: progress.NewSilentReporter()})
+	assert.NoError(t, err)
+	assert.Len(t, requests, 2)
+}
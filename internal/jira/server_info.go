@@ -0,0 +1,111 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+)
+
+// serverInfoMaxAge is how long cached server info is trusted, so that
+// upgrades of Jira are noticed without user intervention.
+const serverInfoMaxAge = 7 * 24 * time.Hour
+
+const deploymentCloud = "Cloud"
+
+// capabilities tells which variants of API configured Jira supports.
+type capabilities struct {
+	// searchJQL is set when search goes through /search/jql paged with
+	// nextPageToken, Jira Cloud removed /search paged with startAt.
+	searchJQL bool
+}
+
+// capabilitiesOf picks API variants from detected server. Deployment type is
+// enough so far: /search/jql exists only on Cloud, Server and Data Center of
+// every version serve /search, and versions not supporting worklog startedAfter
+// ignore it. Version is cached and reported by config show, variants depending
+// on it belong here once there's one.
+func capabilitiesOf(info configuration.ServerInfo) capabilities {
+	return capabilities{searchJQL: info.DeploymentType == deploymentCloud}
+}
+
+// flavourCapabilities are assumed when Jira can't be probed.
+func flavourCapabilities(cloud bool) capabilities {
+	return capabilities{searchJQL: cloud}
+}
+
+// capabilitiesProbe is a request for server info in flight, callers arriving
+// meanwhile wait for it instead of sending their own.
+type capabilitiesProbe struct {
+	done         chan struct{}
+	capabilities capabilities
+}
+
+// GetServerInfo returns version and deployment type of configured Jira.
+// It's cached in config for serverInfoMaxAge, pass refresh to ask Jira again.
+func (c *JiraClient) GetServerInfo(ctx context.Context, refresh bool) (configuration.ServerInfo, error) {
+	if !refresh {
+		if info := c.config.GetServerInfo(); info != nil && time.Since(info.CheckedAt) < serverInfoMaxAge {
+			return *info, nil
+		}
+	}
+	resp, err := c.callGet(ctx, "/rest/api/2/serverInfo")
+	if err != nil {
+		return configuration.ServerInfo{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return configuration.ServerInfo{}, c.newAPIError(resp)
+	}
+	var info configuration.ServerInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return configuration.ServerInfo{}, err
+	}
+	info.CheckedAt = time.Now()
+	// caching is best effort, info is known either way
+	c.config.SetServerInfo(&info)
+	return info, nil
+}
+
+// getCapabilities probes Jira once per client. Lock isn't held during the
+// probe, concurrent callers share a single request. When probing fails
+// capabilities are derived from configured flavour for the rest of the run, so
+// that Jira refusing serverInfo, e.g. behind SSO proxy, neither breaks nor
+// doubles requests. Failures aren't cached in config, next run probes again.
+func (c *JiraClient) getCapabilities(ctx context.Context) capabilities {
+	c.capabilitiesMu.Lock()
+	if c.capabilities != nil {
+		detected := *c.capabilities
+		c.capabilitiesMu.Unlock()
+		return detected
+	}
+	probe := c.capabilitiesProbe
+	if probe != nil {
+		c.capabilitiesMu.Unlock()
+		select {
+		case <-probe.done:
+			return probe.capabilities
+		case <-ctx.Done():
+			return flavourCapabilities(c.isCloud())
+		}
+	}
+	probe = &capabilitiesProbe{done: make(chan struct{})}
+	c.capabilitiesProbe = probe
+	c.capabilitiesMu.Unlock()
+
+	info, err := c.GetServerInfo(ctx, false)
+	if err != nil {
+		probe.capabilities = flavourCapabilities(c.isCloud())
+	} else {
+		probe.capabilities = capabilitiesOf(info)
+	}
+
+	c.capabilitiesMu.Lock()
+	c.capabilities = &probe.capabilities
+	c.capabilitiesMu.Unlock()
+	close(probe.done)
+	return probe.capabilities
+}
@@ -0,0 +1,200 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+// cachedServerInfo is info of Jira of given flavour as cached in config, so
+// that clients don't probe test servers.
+func cachedServerInfo(flavour string) *configuration.ServerInfo {
+	if flavour == configuration.FlavourCloud {
+		return &configuration.ServerInfo{Version: "1001.0.0-SNAPSHOT", VersionNumbers: []int{1001, 0, 0}, DeploymentType: "Cloud", CheckedAt: time.Now()}
+	}
+	return &configuration.ServerInfo{Version: "9.12.0", VersionNumbers: []int{9, 12, 0}, DeploymentType: "DataCenter", CheckedAt: time.Now()}
+}
+
+func TestGetServerInfo(t *testing.T) {
+	tests := []struct {
+		name             string
+		cached           *configuration.ServerInfo
+		refresh          bool
+		expectedVersion  string
+		expectedRequests int
+	}{
+		{name: "NotCached", expectedVersion: "9.12.2", expectedRequests: 1},
+		{name: "Cached", cached: &configuration.ServerInfo{Version: "9.4.0", CheckedAt: time.Now()}, expectedVersion: "9.4.0"},
+		{name: "Stale", cached: &configuration.ServerInfo{Version: "9.4.0", CheckedAt: time.Now().Add(-serverInfoMaxAge)}, expectedVersion: "9.12.2", expectedRequests: 1},
+		{name: "Refresh", cached: &configuration.ServerInfo{Version: "9.4.0", CheckedAt: time.Now()}, refresh: true, expectedVersion: "9.12.2", expectedRequests: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				assert.Equal(t, "/rest/api/2/serverInfo", r.URL.Path)
+				w.Write([]byte(`{"baseUrl": "https://jira.example", "version": "9.12.2", "versionNumbers": [9, 12, 2], "deploymentType": "DataCenter", "buildNumber": 9120002}`))
+			}))
+			defer server.Close()
+
+			client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+				JiraOrigin: server.URL,
+				JiraToken:  "token123",
+				ServerInfo: tt.cached,
+			}), nil)
+			info, err := client.GetServerInfo(context.Background(), tt.refresh)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedVersion, info.Version)
+			assert.Equal(t, tt.expectedRequests, requests)
+		})
+	}
+}
+
+func TestCapabilitiesOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     configuration.ServerInfo
+		expected capabilities
+	}{
+		{name: "Cloud", info: configuration.ServerInfo{VersionNumbers: []int{1001, 0, 0}, DeploymentType: "Cloud"}, expected: capabilities{searchJQL: true}},
+		{name: "Server8", info: configuration.ServerInfo{VersionNumbers: []int{8, 20, 30}, DeploymentType: "Server"}, expected: capabilities{}},
+		{name: "DataCenter10", info: configuration.ServerInfo{VersionNumbers: []int{10, 3, 0}, DeploymentType: "DataCenter"}, expected: capabilities{}},
+		{name: "UnknownVersion", info: configuration.ServerInfo{}, expected: capabilities{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, capabilitiesOf(tt.info))
+		})
+	}
+}
+
+func TestGetAllWorklogs_StartedAfterWithoutProbing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEqual(t, "/rest/api/2/serverInfo", r.URL.Path)
+		assert.NotEmpty(t, r.URL.Query().Get("startedAfter"))
+		w.Write([]byte(`{"worklogs": []}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	}), nil)
+	_, err := client.getAllWorklogs(context.Background(), "TEST-1", 24*time.Hour)
+	assert.NoError(t, err)
+}
+
+func TestSearch_UsesDetectedDeployment(t *testing.T) {
+	tests := []struct {
+		name         string
+		serverInfo   string
+		expectedPath string
+	}{
+		{name: "DetectedCloud", serverInfo: `{"version": "1001.0.0-SNAPSHOT", "versionNumbers": [1001, 0, 0], "deploymentType": "Cloud"}`, expectedPath: "/rest/api/3/search/jql"},
+		{name: "ProbeFailedFallsBackToFlavour", expectedPath: "/rest/api/2/search"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probes := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/rest/api/2/serverInfo" {
+					probes++
+					if tt.serverInfo == "" {
+						w.WriteHeader(http.StatusForbidden)
+						return
+					}
+					w.Write([]byte(tt.serverInfo))
+					return
+				}
+				assert.Equal(t, tt.expectedPath, r.URL.Path)
+				w.Write([]byte(`{"issues": [], "total": 0, "isLast": true}`))
+			}))
+			defer server.Close()
+
+			client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+				JiraOrigin: server.URL,
+				JiraToken:  "token123",
+			}), nil)
+			client.retry.maxAttempts = 1
+			for range 2 {
+				_, err := client.SearchIssues(context.Background(), "project = ABC")
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, 1, probes)
+		})
+	}
+}
+
+func TestSearch_FailedProbeIsNotRepeated(t *testing.T) {
+	probes := 0
+	searches := 0
+	requests := []SearchJql{}
+	paging := newPagingServer(t, 250, 100, &requests)
+	defer paging.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/api/2/serverInfo" {
+			probes++
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		searches++
+		paging.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	}), nil)
+	client.retry.maxAttempts = 1
+	for range 2 {
+		it := client.newSearchIterator("project = ABC", []string{"key"}, client.assertConfigurationIsValid)
+		issues, err := collectIssues(context.Background(), it)
+		assert.NoError(t, err)
+		assert.Len(t, issues, 250)
+	}
+	assert.Equal(t, 6, searches)
+	assert.Equal(t, 1, probes)
+}
+
+func TestGetCapabilities_ConcurrentCallersShareProbe(t *testing.T) {
+	var probes atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		<-release
+		w.Write([]byte(`{"versionNumbers": [1001, 0, 0], "deploymentType": "Cloud"}`))
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	}), nil)
+
+	var wg sync.WaitGroup
+	results := make([]capabilities, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = client.getCapabilities(context.Background())
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), probes.Load())
+	for _, result := range results {
+		assert.Equal(t, capabilities{searchJQL: true}, result)
+	}
+}
@@ -0,0 +1,235 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FilipFl/logit/internal/cache"
+	"github.com/FilipFl/logit/internal/configuration"
+)
+
+// Tempo Timesheets sends and expects times in server local time without zone.
+const tempoTimeFormat = "2006-01-02 15:04:05.000"
+
+// TempoClient stores worklogs through Tempo Timesheets REST API available on
+// Jira Server and Data Center. Everything except logging and listing work is
+// delegated to plain Jira API, Tempo worklogs are regular Jira worklogs too.
+type TempoClient struct {
+	*JiraClient
+}
+
+type tempoIssue struct {
+	ID      int64  `json:"id"`
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+}
+
+type tempoAttributeValue struct {
+	Name            string `json:"name"`
+	WorkAttributeID int    `json:"workAttributeId"`
+	Value           string `json:"value"`
+}
+
+type tempoWorklog struct {
+	TempoWorklogID   int64                          `json:"tempoWorklogId,omitempty"`
+	OriginID         int64                          `json:"originId,omitempty"`
+	Worker           string                         `json:"worker"`
+	OriginTaskID     string                         `json:"originTaskId,omitempty"`
+	Issue            *tempoIssue                    `json:"issue,omitempty"`
+	Started          string                         `json:"started"`
+	TimeSpentSeconds int                            `json:"timeSpentSeconds"`
+	Comment          string                         `json:"comment,omitempty"`
+	Attributes       map[string]tempoAttributeValue `json:"attributes,omitempty"`
+}
+
+type tempoWorklogSearch struct {
+	From   string   `json:"from"`
+	To     string   `json:"to"`
+	Worker []string `json:"worker"`
+}
+
+// WorkAttribute is a custom field Tempo can require on worklogs, e.g. Account.
+type WorkAttribute struct {
+	ID       int    `json:"id"`
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+}
+
+func NewTempoClient(config configuration.Config, worklogCache cache.WorklogCache) *TempoClient {
+	return &TempoClient{JiraClient: NewJiraClient(config, worklogCache)}
+}
+
+// LogTime logs work through Tempo so that work attributes passed in options
+// are stored with the worklog. Returned ID is ID of underlying Jira worklog.
+func (c *TempoClient) LogTime(ctx context.Context, taskKey string, duration time.Duration, started time.Time, comment string, options LogTimeOptions) (string, error) {
+	if options.Visibility != nil {
+		return "", errorVisibilityNotSupportedByTempo
+	}
+	if options.Adjustment.Mode != "" && options.Adjustment.Mode != AdjustEstimateAuto {
+		return "", errorAdjustEstimateNotSupportedByTempo
+	}
+	user, err := c.GetCurrentUser(ctx, false)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errorIdentifyingUser, err)
+	}
+	attributes, err := c.resolveWorkAttributes(ctx, options.Attributes)
+	if err != nil {
+		return "", err
+	}
+	worklog := tempoWorklog{
+		Worker:           user.Key,
+		OriginTaskID:     taskKey,
+		Started:          started.Local().Format(tempoTimeFormat),
+		TimeSpentSeconds: int(duration.Seconds()),
+		Comment:          comment,
+		Attributes:       attributes,
+	}
+	jsonData, err := json.Marshal(worklog)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.callPost(ctx, "/rest/tempo-timesheets/4/worklogs", jsonData, retryRejectedOnly, c.assertTempoConfigurationIsValid)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to log time: %w", c.newAPIError(resp))
+	}
+	var created []tempoWorklog
+	// worklog is logged already, missing ID only disables amending it later
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil || len(created) == 0 || created[0].OriginID == 0 {
+		return "", nil
+	}
+	return strconv.FormatInt(created[0].OriginID, 10), nil
+}
+
+// GetLoggedTime reads user's worklogs from Tempo search, which returns all of
+// them in a single request so local cache isn't needed.
+func (c *TempoClient) GetLoggedTime(ctx context.Context, query LoggedTimeQuery) (Logs, error) {
+	user, err := c.GetCurrentUser(ctx, false)
+	if err != nil {
+		return Logs{}, fmt.Errorf("%w: %w", errorIdentifyingUser, err)
+	}
+	now := time.Now()
+	boundary := now.AddDate(0, 0, -(query.FromDays))
+	query.Reporter.Start(1)
+	defer query.Reporter.Finish()
+	tempoWorklogs, err := c.searchWorklogs(ctx, user, boundary, now)
+	if err != nil {
+		return Logs{}, fmt.Errorf("%w: %w", errorFetchingWorklogs, err)
+	}
+	query.Reporter.Increment()
+
+	worklogs := make([]cache.Worklog, 0, len(tempoWorklogs))
+	for _, log := range tempoWorklogs {
+		started, err := time.ParseInLocation(tempoTimeFormat, log.Started, time.Local)
+		if err != nil || log.Issue == nil {
+			continue
+		}
+		worklogs = append(worklogs, cache.Worklog{
+			ID:               strconv.FormatInt(log.OriginID, 10),
+			IssueID:          strconv.FormatInt(log.Issue.ID, 10),
+			IssueKey:         log.Issue.Key,
+			IssueSummary:     log.Issue.Summary,
+			Started:          started,
+			TimeSpentSeconds: log.TimeSpentSeconds,
+		})
+	}
+	return logsFromWorklogs(worklogs, boundary), nil
+}
+
+func (c *TempoClient) searchWorklogs(ctx context.Context, user configuration.JiraUser, from, to time.Time) ([]tempoWorklog, error) {
+	jsonData, err := json.Marshal(tempoWorklogSearch{
+		From:   from.Format(time.DateOnly),
+		To:     to.Format(time.DateOnly),
+		Worker: []string{user.Key},
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.callPost(ctx, "/rest/tempo-timesheets/4/worklogs/search", jsonData, retryIdempotent, c.assertTempoConfigurationIsValid)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.newAPIError(resp)
+	}
+	var worklogs []tempoWorklog
+	if err := json.NewDecoder(resp.Body).Decode(&worklogs); err != nil {
+		return nil, err
+	}
+	return worklogs, nil
+}
+
+func (c *TempoClient) GetWorkAttributes(ctx context.Context) ([]WorkAttribute, error) {
+	if err := c.assertTempoConfigurationIsValid(); err != nil {
+		return nil, err
+	}
+	resp, err := c.callGet(ctx, "/rest/tempo-core/1/work-attribute")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.newAPIError(resp)
+	}
+	var attributes []WorkAttribute
+	if err := json.NewDecoder(resp.Body).Decode(&attributes); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+// resolveWorkAttributes turns attribute values passed by name or key into
+// payload expected by Tempo.
+func (c *TempoClient) resolveWorkAttributes(ctx context.Context, values map[string]string) (map[string]tempoAttributeValue, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	attributes, err := c.GetWorkAttributes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolved := map[string]tempoAttributeValue{}
+	for name, value := range values {
+		index := slices.IndexFunc(attributes, func(a WorkAttribute) bool {
+			return strings.EqualFold(a.Name, name) || a.Key == name
+		})
+		if index < 0 {
+			names := []string{}
+			for _, attribute := range attributes {
+				names = append(names, attribute.Name)
+			}
+			return nil, fmt.Errorf("%w %q, available: %s", errorUnknownWorkAttribute, name, strings.Join(names, ", "))
+		}
+		attribute := attributes[index]
+		resolved[attribute.Key] = tempoAttributeValue{
+			Name:            attribute.Name,
+			WorkAttributeID: attribute.ID,
+			Value:           value,
+		}
+	}
+	return resolved, nil
+}
+
+func (c *TempoClient) assertTempoConfigurationIsValid() error {
+	if err := c.assertConfigurationIsValid(); err != nil {
+		return err
+	}
+	if c.isCloud() {
+		return errorTempoRequiresServer
+	}
+	return nil
+}
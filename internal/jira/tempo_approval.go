@@ -0,0 +1,140 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Statuses of Tempo timesheet approval.
+const (
+	TimesheetOpen               = "open"
+	TimesheetReadyToSubmit      = "ready_to_submit"
+	TimesheetWaitingForApproval = "waiting_for_approval"
+	TimesheetApproved           = "approved"
+	TimesheetRejected           = "rejected"
+)
+
+type TempoUser struct {
+	Key         string `json:"key"`
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+type TimesheetPeriod struct {
+	DateFrom string `json:"dateFrom"`
+	DateTo   string `json:"dateTo"`
+}
+
+// TimesheetApproval describes approval state of user's timesheet in a period.
+type TimesheetApproval struct {
+	Status          string          `json:"status"`
+	WorkedSeconds   int             `json:"workedSeconds"`
+	RequiredSeconds int             `json:"requiredSeconds"`
+	Period          TimesheetPeriod `json:"period"`
+	Reviewer        *TempoUser      `json:"reviewer"`
+}
+
+// IsSubmitted tells whether timesheet was already sent for approval.
+func (a TimesheetApproval) IsSubmitted() bool {
+	return a.Status == TimesheetWaitingForApproval || a.Status == TimesheetApproved
+}
+
+type timesheetApprovalAction struct {
+	Name     string     `json:"name"`
+	Comment  string     `json:"comment,omitempty"`
+	Reviewer *TempoUser `json:"reviewer,omitempty"`
+}
+
+type timesheetApprovalRequest struct {
+	User   TempoUser               `json:"user"`
+	Period map[string]string       `json:"period"`
+	Action timesheetApprovalAction `json:"action"`
+}
+
+// GetTimesheetApproval returns approval of current user's timesheet for the
+// Tempo period containing day.
+func (c *TempoClient) GetTimesheetApproval(ctx context.Context, day time.Time) (TimesheetApproval, error) {
+	if err := c.assertTempoConfigurationIsValid(); err != nil {
+		return TimesheetApproval{}, err
+	}
+	user, err := c.GetCurrentUser(ctx, false)
+	if err != nil {
+		return TimesheetApproval{}, fmt.Errorf("%w: %w", errorIdentifyingUser, err)
+	}
+	endpoint := fmt.Sprintf("/rest/tempo-timesheets/4/timesheet-approval/user/%s?periodStartDate=%s", url.PathEscape(user.Key), day.Format(time.DateOnly))
+	resp, err := c.callGet(ctx, endpoint)
+	if err != nil {
+		return TimesheetApproval{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return TimesheetApproval{}, c.newAPIError(resp)
+	}
+	var approval TimesheetApproval
+	if err := json.NewDecoder(resp.Body).Decode(&approval); err != nil {
+		return TimesheetApproval{}, err
+	}
+	return approval, nil
+}
+
+// SubmitTimesheet sends current user's timesheet for the period containing
+// day for approval. Empty reviewerKey leaves choice of reviewer to Tempo.
+func (c *TempoClient) SubmitTimesheet(ctx context.Context, day time.Time, reviewerKey, comment string) (TimesheetApproval, error) {
+	user, err := c.GetCurrentUser(ctx, false)
+	if err != nil {
+		return TimesheetApproval{}, fmt.Errorf("%w: %w", errorIdentifyingUser, err)
+	}
+	request := timesheetApprovalRequest{
+		User:   TempoUser{Key: user.Key},
+		Period: map[string]string{"periodStartDate": day.Format(time.DateOnly)},
+		Action: timesheetApprovalAction{Name: "submit", Comment: comment},
+	}
+	if reviewerKey != "" {
+		request.Action.Reviewer = &TempoUser{Key: reviewerKey}
+	}
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return TimesheetApproval{}, err
+	}
+	resp, err := c.callPost(ctx, "/rest/tempo-timesheets/4/timesheet-approval", jsonData, retryRejectedOnly, c.assertTempoConfigurationIsValid)
+	if err != nil {
+		return TimesheetApproval{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return TimesheetApproval{}, fmt.Errorf("failed to submit timesheet: %w", c.newAPIError(resp))
+	}
+	var approval TimesheetApproval
+	if err := json.NewDecoder(resp.Body).Decode(&approval); err != nil {
+		return TimesheetApproval{}, err
+	}
+	return approval, nil
+}
+
+// GetWorkedDays sums current user's logged time per day, keyed by date in
+// format 2006-01-02, between from and to inclusive.
+func (c *TempoClient) GetWorkedDays(ctx context.Context, from, to time.Time) (map[string]time.Duration, error) {
+	user, err := c.GetCurrentUser(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errorIdentifyingUser, err)
+	}
+	worklogs, err := c.searchWorklogs(ctx, user, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errorFetchingWorklogs, err)
+	}
+	days := map[string]time.Duration{}
+	for _, worklog := range worklogs {
+		started, err := time.ParseInLocation(tempoTimeFormat, worklog.Started, time.Local)
+		if err != nil {
+			continue
+		}
+		days[started.Format(time.DateOnly)] += time.Duration(worklog.TimeSpentSeconds) * time.Second
+	}
+	return days, nil
+}
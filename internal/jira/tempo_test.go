@@ -0,0 +1,200 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/progress"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTempoServer struct {
+	logged   *tempoWorklog
+	searched *tempoWorklogSearch
+	worklogs string
+}
+
+func (f *fakeTempoServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/rest/tempo-core/1/work-attribute":
+		w.Write([]byte(`[{"id": 1, "key": "_Account_", "name": "Account", "required": true}, {"id": 2, "key": "_WorkType_", "name": "Work Type"}]`))
+	case "/rest/tempo-timesheets/4/worklogs":
+		f.logged = &tempoWorklog{}
+		json.NewDecoder(r.Body).Decode(f.logged)
+		w.Write([]byte(`[{"tempoWorklogId": 7, "originId": 10100}]`))
+	case "/rest/tempo-timesheets/4/worklogs/search":
+		f.searched = &tempoWorklogSearch{}
+		json.NewDecoder(r.Body).Decode(f.searched)
+		w.Write([]byte(f.worklogs))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTempoTestClient(origin string) *TempoClient {
+	return NewTempoClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: origin,
+		JiraToken:  "token123",
+		JiraUser:   &configuration.JiraUser{Key: "JIRAUSER10000"},
+		Backend:    configuration.BackendTempo,
+	}), nil)
+}
+
+func TestTempoLogTime(t *testing.T) {
+	fake := &fakeTempoServer{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := newTempoTestClient(server.URL)
+	started := time.Date(2026, 10, 16, 9, 30, 0, 0, time.Local)
+	options := LogTimeOptions{Attributes: map[string]string{"account": "ACC-1", "_WorkType_": "Development"}}
+	worklogID, err := client.LogTime(context.Background(), "PROJ-1", 90*time.Minute, started, "Review", options)
+	assert.NoError(t, err)
+	assert.Equal(t, "10100", worklogID)
+	assert.Equal(t, "JIRAUSER10000", fake.logged.Worker)
+	assert.Equal(t, "PROJ-1", fake.logged.OriginTaskID)
+	assert.Equal(t, "2026-10-16 09:30:00.000", fake.logged.Started)
+	assert.Equal(t, 5400, fake.logged.TimeSpentSeconds)
+	assert.Equal(t, map[string]tempoAttributeValue{
+		"_Account_":  {Name: "Account", WorkAttributeID: 1, Value: "ACC-1"},
+		"_WorkType_": {Name: "Work Type", WorkAttributeID: 2, Value: "Development"},
+	}, fake.logged.Attributes)
+}
+
+func TestTempoLogTime_Rejected(t *testing.T) {
+	fake := &fakeTempoServer{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		options       LogTimeOptions
+		expectedError error
+	}{
+		{
+			name:          "UnknownAttribute",
+			options:       LogTimeOptions{Attributes: map[string]string{"Cost center": "1"}},
+			expectedError: errorUnknownWorkAttribute,
+		},
+		{
+			name:          "Visibility",
+			options:       LogTimeOptions{Visibility: &Visibility{Type: VisibilityRole, Value: "Developers"}},
+			expectedError: errorVisibilityNotSupportedByTempo,
+		},
+		{
+			name:          "AdjustEstimate",
+			options:       LogTimeOptions{Adjustment: EstimateAdjustment{Mode: AdjustEstimateLeave}},
+			expectedError: errorAdjustEstimateNotSupportedByTempo,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.logged = nil
+			client := newTempoTestClient(server.URL)
+			_, err := client.LogTime(context.Background(), "PROJ-1", time.Hour, time.Now(), "", tt.options)
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Nil(t, fake.logged)
+		})
+	}
+}
+
+func TestTempoLogTime_RequiresServer(t *testing.T) {
+	client := NewTempoClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin:  "https://example.atlassian.net",
+		JiraToken:   "token123",
+		JiraEmail:   "me@example.com",
+		JiraFlavour: configuration.FlavourCloud,
+		JiraUser:    &configuration.JiraUser{AccountID: "abc"},
+	}), nil)
+	_, err := client.LogTime(context.Background(), "PROJ-1", time.Hour, time.Now(), "", LogTimeOptions{})
+	assert.ErrorIs(t, err, errorTempoRequiresServer)
+}
+
+func TestTempoGetLoggedTime(t *testing.T) {
+	today := time.Now().Format(time.DateOnly)
+	fake := &fakeTempoServer{
+		worklogs: `[
+			{"originId": 1, "issue": {"id": 10001, "key": "PROJ-1", "summary": "First"}, "started": "` + today + ` 09:00:00.000", "timeSpentSeconds": 3600},
+			{"originId": 2, "issue": {"id": 10002, "key": "PROJ-2", "summary": "Second"}, "started": "` + today + ` 11:00:00.000", "timeSpentSeconds": 1800}
+		]`,
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := newTempoTestClient(server.URL)
+	reporter := progress.NewMockReporter()
+	logs, err := client.GetLoggedTime(context.Background(), LoggedTimeQuery{FromDays: 7, Reporter: reporter})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"JIRAUSER10000"}, fake.searched.Worker)
+	assert.Equal(t, today, fake.searched.To)
+	assert.Len(t, logs.Days, 1)
+	assert.Equal(t, 90*time.Minute, logs.Days[0].TimeLogged)
+	assert.True(t, reporter.Finished)
+}
+
+func TestLogTime_AttributesRequireTempo(t *testing.T) {
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: "https://jira.example.com",
+		JiraToken:  "token123",
+	}), nil)
+	_, err := client.LogTime(context.Background(), "PROJ-1", time.Hour, time.Now(), "", LogTimeOptions{Attributes: map[string]string{"Account": "ACC-1"}})
+	assert.ErrorIs(t, err, errorAttributesRequireTempo)
+}
+
+func TestTempoTimesheetApproval(t *testing.T) {
+	var submitted timesheetApprovalRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/rest/tempo-timesheets/4/timesheet-approval/user/JIRAUSER10000":
+			assert.Equal(t, "2026-10-01", r.URL.Query().Get("periodStartDate"))
+			w.Write([]byte(`{"status": "open", "workedSeconds": 36000, "requiredSeconds": 28800,
+				"period": {"dateFrom": "2026-10-01", "dateTo": "2026-10-31"}, "reviewer": {"key": "boss", "displayName": "The Boss"}}`))
+		case r.Method == "POST" && r.URL.Path == "/rest/tempo-timesheets/4/timesheet-approval":
+			json.NewDecoder(r.Body).Decode(&submitted)
+			w.Write([]byte(`{"status": "waiting_for_approval", "workedSeconds": 36000, "period": {"dateFrom": "2026-10-01", "dateTo": "2026-10-31"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTempoTestClient(server.URL)
+	period := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	approval, err := client.GetTimesheetApproval(context.Background(), period)
+	assert.NoError(t, err)
+	assert.Equal(t, TimesheetOpen, approval.Status)
+	assert.False(t, approval.IsSubmitted())
+	assert.Equal(t, "The Boss", approval.Reviewer.DisplayName)
+	assert.Equal(t, 36000, approval.WorkedSeconds)
+
+	approval, err = client.SubmitTimesheet(context.Background(), period, "boss", "October")
+	assert.NoError(t, err)
+	assert.True(t, approval.IsSubmitted())
+	assert.Equal(t, "JIRAUSER10000", submitted.User.Key)
+	assert.Equal(t, "2026-10-01", submitted.Period["periodStartDate"])
+	assert.Equal(t, timesheetApprovalAction{Name: "submit", Comment: "October", Reviewer: &TempoUser{Key: "boss"}}, submitted.Action)
+}
+
+func TestTempoGetWorkedDays(t *testing.T) {
+	fake := &fakeTempoServer{
+		worklogs: `[
+			{"originId": 1, "issue": {"key": "PROJ-1"}, "started": "2026-10-01 09:00:00.000", "timeSpentSeconds": 3600},
+			{"originId": 2, "issue": {"key": "PROJ-2"}, "started": "2026-10-01 13:00:00.000", "timeSpentSeconds": 1800},
+			{"originId": 3, "issue": {"key": "PROJ-2"}, "started": "2026-10-02 13:00:00.000", "timeSpentSeconds": 1800}
+		]`,
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := newTempoTestClient(server.URL)
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	days, err := client.GetWorkedDays(context.Background(), from, from.AddDate(0, 1, -1))
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-10-31", fake.searched.To)
+	assert.Equal(t, map[string]time.Duration{"2026-10-01": 90 * time.Minute, "2026-10-02": 30 * time.Minute}, days)
+}
@@ -0,0 +1,327 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	redacted           = "[REDACTED]"
+	debugBodyLimit     = 1024
+	traceCreator       = "logit"
+	traceFormatVersion = "1.2"
+)
+
+// sensitiveHeaders never leave tracer with their values.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+var sensitiveQueryParam = regexp.MustCompile(`(?i)^(.*token.*|.*secret.*|password|oauth_signature|oauth_verifier)$`)
+var sensitiveJSONField = regexp.MustCompile(`(?i)("[^"]*(?:token|secret|password)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// sensitiveFormField matches credentials in form encoded bodies, e.g. tokens
+// issued during OAuth authorization.
+var sensitiveFormField = regexp.MustCompile(`(?i)((?:^|&)[^&=\s]*(?:token|secret|verifier)[^&=\s]*=)[^&\s]*`)
+
+// Tracer records HTTP exchanges with Jira. It logs summary of each exchange
+// to debug writer, when set, and keeps full exchanges for WriteHAR when
+// recording. Credentials are redacted in both.
+type Tracer struct {
+	debug  io.Writer
+	record bool
+
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+// NewTracer returns tracer logging to debug (nil disables logging) and
+// keeping exchanges in memory when record is set.
+func NewTracer(debug io.Writer, record bool) *Tracer {
+	return &Tracer{debug: debug, record: record, entries: []harEntry{}}
+}
+
+// WriteHAR writes recorded exchanges as HAR (HTTP Archive) JSON.
+func (t *Tracer) WriteHAR(w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	har := harFile{Log: harLog{
+		Version: traceFormatVersion,
+		Creator: harCreator{Name: traceCreator},
+		Entries: t.entries,
+	}}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(har)
+}
+
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            int64       `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Timings         harTimings  `json:"timings"`
+	// Error isn't part of HAR, it holds transport failure when no response came.
+	Error string `json:"_error,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    int64 `json:"send"`
+	Wait    int64 `json:"wait"`
+	Receive int64 `json:"receive"`
+}
+
+// redactor hides credentials in traced exchanges.
+type redactor struct {
+	secrets []string
+}
+
+func newRedactor(secrets ...string) redactor {
+	r := redactor{}
+	for _, secret := range secrets {
+		if secret != "" {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+	return r
+}
+
+func (r redactor) text(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	s = sensitiveJSONField.ReplaceAllString(s, `$1"`+redacted+`"`)
+	return sensitiveFormField.ReplaceAllString(s, "${1}"+redacted)
+}
+
+func (r redactor) url(u *url.URL) string {
+	redactedURL := *u
+	redactedURL.User = nil
+	query := redactedURL.Query()
+	for name := range query {
+		if sensitiveQueryParam.MatchString(name) {
+			query[name] = []string{redacted}
+		}
+	}
+	if len(query) > 0 {
+		redactedURL.RawQuery = query.Encode()
+	}
+	return r.text(redactedURL.String())
+}
+
+func (r redactor) headers(header http.Header) []harNameValue {
+	headers := []harNameValue{}
+	for name, values := range header {
+		for _, value := range values {
+			if isSensitiveHeader(name) {
+				value = redacted
+			}
+			headers = append(headers, harNameValue{Name: name, Value: r.text(value)})
+		}
+	}
+	return headers
+}
+
+func isSensitiveHeader(name string) bool {
+	for _, sensitive := range sensitiveHeaders {
+		if strings.EqualFold(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// tracingTransport passes requests to next transport and reports them to tracer.
+type tracingTransport struct {
+	next     http.RoundTripper
+	tracer   *Tracer
+	redactor redactor
+	now      func() time.Time
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	started := t.now()
+	resp, err := t.next.RoundTrip(req)
+	var responseBody []byte
+	if err == nil {
+		responseBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	}
+	elapsed := t.now().Sub(started)
+	t.tracer.add(t.entry(req, requestBody, resp, responseBody, err, started, elapsed))
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// peekRequestBody reads request body leaving it in place for the transport.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func (t *tracingTransport) entry(req *http.Request, requestBody []byte, resp *http.Response, responseBody []byte, err error, started time.Time, elapsed time.Duration) harEntry {
+	entry := harEntry{
+		StartedDateTime: started,
+		Time:            elapsed.Milliseconds(),
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         t.redactor.url(req.URL),
+			HTTPVersion: req.Proto,
+			Headers:     t.redactor.headers(req.Header),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(requestBody),
+		},
+		Response: harResponse{Headers: []harNameValue{}, HeadersSize: -1, BodySize: -1},
+		Timings:  harTimings{Wait: elapsed.Milliseconds()},
+	}
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			if sensitiveQueryParam.MatchString(name) {
+				value = redacted
+			}
+			entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: t.redactor.text(value)})
+		}
+	}
+	if requestBody != nil {
+		entry.Request.PostData = &harPostData{MimeType: req.Header.Get("Content-Type"), Text: t.redactor.text(string(requestBody))}
+	}
+	if err != nil {
+		entry.Error = t.redactor.text(err.Error())
+		return entry
+	}
+	entry.Response = harResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: resp.Proto,
+		Headers:     t.redactor.headers(resp.Header),
+		Content: harContent{
+			Size:     len(responseBody),
+			MimeType: resp.Header.Get("Content-Type"),
+			Text:     t.redactor.text(string(responseBody)),
+		},
+		RedirectURL: resp.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    len(responseBody),
+	}
+	return entry
+}
+
+func (t *Tracer) add(entry harEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.debug != nil {
+		fmt.Fprintln(t.debug, debugLine(entry))
+	}
+	if t.record {
+		t.entries = append(t.entries, entry)
+	}
+}
+
+// debugLine summarises exchange in a single line followed by truncated
+// request and response bodies.
+func debugLine(entry harEntry) string {
+	line := fmt.Sprintf("[debug] %s %s", entry.Request.Method, entry.Request.URL)
+	if entry.Error != "" {
+		line = fmt.Sprintf("%s failed after %dms: %s", line, entry.Time, entry.Error)
+	} else {
+		line = fmt.Sprintf("%s -> %d (%dms)", line, entry.Response.Status, entry.Time)
+	}
+	if entry.Request.PostData != nil {
+		if body := truncateBody(entry.Request.PostData.Text); body != "" {
+			line += "\n        request: " + body
+		}
+	}
+	if body := truncateBody(entry.Response.Content.Text); body != "" {
+		line += "\n        response: " + body
+	}
+	return line
+}
+
+// truncateBody cuts body to debugBodyLimit bytes without splitting a character.
+func truncateBody(body string) string {
+	body = strings.TrimSpace(body)
+	if len(body) <= debugBodyLimit {
+		return body
+	}
+	cut := debugBodyLimit
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... (%d bytes truncated)", body[:cut], len(body)-cut)
+}
@@ -0,0 +1,97 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Transition moves issue from its current status to another one in workflow.
+type Transition struct {
+	ID     string               `json:"id"`
+	Name   string               `json:"name"`
+	To     JiraStatus           `json:"to"`
+	Fields map[string]FieldMeta `json:"fields"`
+}
+
+// FieldMeta describes a field shown on transition or create screen.
+type FieldMeta struct {
+	Name          string         `json:"name"`
+	Required      bool           `json:"required"`
+	HasDefault    bool           `json:"hasDefaultValue"`
+	Schema        FieldSchema    `json:"schema"`
+	AllowedValues []AllowedValue `json:"allowedValues"`
+}
+
+// FieldSchema tells type of field value, Type is "array" for multi-value
+// fields like components.
+type FieldSchema struct {
+	Type  string `json:"type"`
+	Items string `json:"items"`
+}
+
+// AllowedValue is one of values a field can be set to. Depending on field
+// it's described by Name (e.g. resolution) or Value (e.g. select list).
+type AllowedValue struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (v AllowedValue) Label() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return v.Value
+}
+
+type transitionsResponse struct {
+	Transitions []Transition `json:"transitions"`
+}
+
+type transitionRequest struct {
+	Transition struct {
+		ID string `json:"id"`
+	} `json:"transition"`
+	Fields map[string]any `json:"fields,omitempty"`
+}
+
+// GetTransitions lists transitions available for issue in its current status
+// along with fields they show.
+func (c *JiraClient) GetTransitions(ctx context.Context, taskKey string) ([]Transition, error) {
+	resp, err := c.callGet(ctx, c.apiPath(fmt.Sprintf("/issue/%s/transitions?expand=transitions.fields", taskKey)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.newAPIError(resp)
+	}
+	var transitions transitionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&transitions); err != nil {
+		return nil, err
+	}
+	return transitions.Transitions, nil
+}
+
+// TransitionIssue performs transition setting fields required by it.
+func (c *JiraClient) TransitionIssue(ctx context.Context, taskKey, transitionID string, fields map[string]any) error {
+	request := transitionRequest{Fields: fields}
+	request.Transition.ID = transitionID
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	resp, err := c.callPost(ctx, c.apiPath(fmt.Sprintf("/issue/%s/transitions", taskKey)), jsonData, retryRejectedOnly, c.assertConfigurationIsValid)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to transition issue: %w", c.newAPIError(resp))
+	}
+	return nil
+}
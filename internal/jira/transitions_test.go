@@ -0,0 +1,48 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitions(t *testing.T) {
+	var performed map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/issue/TEST-1/transitions", r.URL.Path)
+		if r.Method == "GET" {
+			assert.Equal(t, "transitions.fields", r.URL.Query().Get("expand"))
+			w.Write([]byte(`{"transitions": [
+				{"id": "21", "name": "Start review", "to": {"name": "In Review"}},
+				{"id": "31", "name": "Done", "to": {"name": "Done"}, "fields": {
+					"resolution": {"name": "Resolution", "required": true, "allowedValues": [{"id": "1", "name": "Fixed"}]}}}]}`))
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&performed)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+	}), nil)
+	transitions, err := client.GetTransitions(context.Background(), "TEST-1")
+	assert.NoError(t, err)
+	assert.Len(t, transitions, 2)
+	assert.Equal(t, "In Review", transitions[0].To.Name)
+	assert.True(t, transitions[1].Fields["resolution"].Required)
+	assert.Equal(t, "Fixed", transitions[1].Fields["resolution"].AllowedValues[0].Label())
+
+	err = client.TransitionIssue(context.Background(), "TEST-1", "31", map[string]any{"resolution": map[string]string{"id": "1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"transition": map[string]any{"id": "31"},
+		"fields":     map[string]any{"resolution": map[string]any{"id": "1"}},
+	}, performed)
+}
@@ -0,0 +1,97 @@
+package jira
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/FilipFl/logit/internal/configuration"
+)
+
+// newTransport builds transport honouring proxy, trusted CAs and client
+// certificate from connection settings.
+func newTransport(connection configuration.Connection) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if connection.Proxy != "" {
+		proxy, err := parseProxyURL(connection.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: connection.InsecureSkipVerify,
+	}
+	if connection.CABundle != "" {
+		pool, err := loadCABundle(connection.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if connection.ClientCert != "" || connection.ClientKey != "" {
+		if connection.ClientCert == "" || connection.ClientKey == "" {
+			return nil, errorIncompleteClientCert
+		}
+		cert, err := tls.LoadX509KeyPair(connection.ClientCert, connection.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errorLoadingClientCert, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// parseProxyURL validates proxy URL, logit supports http, https and socks5 proxies.
+func parseProxyURL(s string) (*url.URL, error) {
+	proxy, err := url.Parse(s)
+	if err != nil || proxy.Host == "" {
+		return nil, fmt.Errorf("%w %q", errorInvalidProxy, s)
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5":
+		return proxy, nil
+	}
+	return nil, fmt.Errorf("%w %q", errorInvalidProxy, s)
+}
+
+// loadCABundle returns system certificate pool extended with certificates from path.
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errorLoadingCABundle, err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%w: no PEM certificates found in %s", errorLoadingCABundle, path)
+	}
+	return pool, nil
+}
+
+// isLoopback tells whether origin points to this machine, where plain http
+// doesn't expose the token.
+func isLoopback(origin *url.URL) bool {
+	host := origin.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ValidateConnection checks whether connection settings can be used, e.g.
+// before they're saved.
+func ValidateConnection(connection configuration.Connection) error {
+	_, err := newTransport(connection)
+	return err
+}
@@ -0,0 +1,233 @@
+package jira
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTransportTestClient(origin string, connection configuration.Connection) *JiraClient {
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: origin,
+		JiraToken:  "token123",
+		Connection: &connection,
+	}), nil)
+	client.retry.maxAttempts = 1
+	return client
+}
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	assert.NoError(t, err)
+	return path
+}
+
+// newClientCertificate issues client certificate signed by freshly generated CA,
+// returns pool with the CA and paths to certificate and key files.
+func newClientCertificate(t *testing.T) (*x509.CertPool, string, string) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "logit test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	assert.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	assert.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "logit"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	return pool, writePEM(t, "client.crt", "CERTIFICATE", der), writePEM(t, "client.key", "EC PRIVATE KEY", keyDER)
+}
+
+func timeTrackingHandler(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(`{"key": "TEST-1", "fields": {"timetracking": {"timeSpent": "1h"}}}`))
+}
+
+func TestTransport_CABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(timeTrackingHandler))
+	defer server.Close()
+	bundle := writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+
+	tests := []struct {
+		name       string
+		connection configuration.Connection
+		wantErr    bool
+	}{
+		{name: "untrusted certificate", connection: configuration.Connection{}, wantErr: true},
+		{name: "certificate trusted via CA bundle", connection: configuration.Connection{CABundle: bundle}},
+		{name: "verification skipped", connection: configuration.Connection{InsecureSkipVerify: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTransportTestClient(server.URL, tt.connection)
+			tracking, err := client.GetTimeTracking(context.Background(), "TEST-1")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "1h", tracking.TimeSpent)
+		})
+	}
+}
+
+func TestTransport_ClientCertificate(t *testing.T) {
+	pool, certPath, keyPath := newClientCertificate(t)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(timeTrackingHandler))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	server.StartTLS()
+	defer server.Close()
+	bundle := writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+
+	client := newTransportTestClient(server.URL, configuration.Connection{CABundle: bundle, ClientCert: certPath, ClientKey: keyPath})
+	tracking, err := client.GetTimeTracking(context.Background(), "TEST-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1h", tracking.TimeSpent)
+
+	client = newTransportTestClient(server.URL, configuration.Connection{CABundle: bundle})
+	_, err = client.GetTimeTracking(context.Background(), "TEST-1")
+	assert.Error(t, err)
+}
+
+func TestTransport_Proxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "jira.example", r.URL.Host)
+		assert.Equal(t, "Bearer token123", r.Header.Get("Authorization"))
+		timeTrackingHandler(w, r)
+	}))
+	defer proxy.Close()
+
+	client := newTransportTestClient("http://jira.example", configuration.Connection{Proxy: proxy.URL, AllowInsecureHTTP: true})
+	tracking, err := client.GetTimeTracking(context.Background(), "TEST-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1h", tracking.TimeSpent)
+}
+
+func TestAssertConfigurationIsValid_Connection(t *testing.T) {
+	tests := []struct {
+		name       string
+		origin     string
+		flavour    string
+		connection configuration.Connection
+		want       error
+	}{
+		{name: "plain http to remote origin", origin: "http://jira.example", want: errorInsecureHTTP},
+		{name: "plain http explicitly allowed", origin: "http://jira.example", connection: configuration.Connection{AllowInsecureHTTP: true}},
+		{name: "plain http to localhost", origin: "http://localhost:8080"},
+		{name: "plain http to loopback address", origin: "http://127.0.0.1:8080"},
+		{name: "skip verify on server", origin: "https://jira.example", connection: configuration.Connection{InsecureSkipVerify: true}},
+		{name: "skip verify on cloud", origin: "https://example.atlassian.net", flavour: configuration.FlavourCloud, connection: configuration.Connection{InsecureSkipVerify: true}, want: errorInsecureSkipVerifyOnCloud},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+				JiraOrigin:  tt.origin,
+				JiraToken:   "token123",
+				JiraEmail:   "user@example.com",
+				JiraFlavour: tt.flavour,
+				Connection:  &tt.connection,
+			}), nil)
+			assert.Equal(t, tt.want, client.assertConfigurationIsValid())
+		})
+	}
+}
+
+func TestValidateConnection(t *testing.T) {
+	tests := []struct {
+		name       string
+		connection configuration.Connection
+		want       error
+	}{
+		{name: "empty", connection: configuration.Connection{}},
+		{name: "socks5 proxy", connection: configuration.Connection{Proxy: "socks5://proxy.example:1080"}},
+		{name: "unsupported proxy scheme", connection: configuration.Connection{Proxy: "ftp://proxy.example"}, want: errorInvalidProxy},
+		{name: "proxy without host", connection: configuration.Connection{Proxy: "proxy.example"}, want: errorInvalidProxy},
+		{name: "missing CA bundle", connection: configuration.Connection{CABundle: "/nonexistent/ca.pem"}, want: errorLoadingCABundle},
+		{name: "certificate without key", connection: configuration.Connection{ClientCert: "client.crt"}, want: errorIncompleteClientCert},
+		{name: "missing certificate", connection: configuration.Connection{ClientCert: "/nonexistent/client.crt", ClientKey: "/nonexistent/client.key"}, want: errorLoadingClientCert},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConnection(tt.connection)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// OAuth authorization doesn't authenticate like API requests
+		assert.Empty(t, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+	bundle := writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+
+	client, err := newTransportTestClient(server.URL, configuration.Connection{CABundle: bundle}).HTTPClient()
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, client.Timeout)
+	resp, err := client.Post(server.URL+"/plugins/servlet/oauth/request-token", "application/x-www-form-urlencoded", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	_, err = newTransportTestClient("http://jira.example", configuration.Connection{}).HTTPClient()
+	assert.Equal(t, errorInsecureHTTP, err)
+	_, err = newTransportTestClient(server.URL, configuration.Connection{CABundle: "/nonexistent/ca.pem"}).HTTPClient()
+	assert.ErrorIs(t, err, errorLoadingCABundle)
+}
+
+func TestHTTPClient_SharedByAllRequests(t *testing.T) {
+	tempo := NewTempoClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin:  "https://jira.example",
+		JiraToken:   "token123",
+		JiraTimeout: "5s",
+	}), nil)
+	client, err := tempo.HTTPClient()
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, client.Timeout)
+
+	apiClient, err := tempo.JiraClient.httpClient()
+	assert.NoError(t, err)
+	assert.Same(t, client, apiClient)
+}
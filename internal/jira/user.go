@@ -0,0 +1,52 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/FilipFl/logit/internal/configuration"
+)
+
+// GetCurrentUser returns user authenticated with configured credentials.
+// User is cached in config, pass refresh to ask Jira again.
+func (c *JiraClient) GetCurrentUser(ctx context.Context, refresh bool) (configuration.JiraUser, error) {
+	if !refresh {
+		if user := c.config.GetJiraUser(); user != nil {
+			return *user, nil
+		}
+	}
+	resp, err := c.callGet(ctx, c.apiPath("/myself"))
+	if err != nil {
+		return configuration.JiraUser{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return configuration.JiraUser{}, c.newAPIError(resp)
+	}
+	var user configuration.JiraUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return configuration.JiraUser{}, err
+	}
+	// caching is best effort, user is known either way
+	c.config.SetJiraUser(&user)
+	return user, nil
+}
+
+// isSameUser tells if author is the user. Identifiers are compared from the
+// most to the least reliable one as Jira may hide some of them.
+func isSameUser(user configuration.JiraUser, author JiraAuthor) bool {
+	switch {
+	case user.AccountID != "" && author.AccountID != "":
+		return user.AccountID == author.AccountID
+	case user.Key != "" && author.Key != "":
+		return user.Key == author.Key
+	case user.Name != "" && author.Name != "":
+		return strings.EqualFold(user.Name, author.Name)
+	case user.EmailAddress != "" && author.Email != "":
+		return strings.EqualFold(user.EmailAddress, author.Email)
+	}
+	return false
+}
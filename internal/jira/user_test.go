@@ -0,0 +1,137 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/progress"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCurrentUser(t *testing.T) {
+	tests := []struct {
+		name             string
+		flavour          string
+		cached           *configuration.JiraUser
+		refresh          bool
+		expectedPath     string
+		expectedUser     configuration.JiraUser
+		expectedRequests int
+	}{
+		{
+			name:             "Server",
+			flavour:          configuration.FlavourServer,
+			expectedPath:     "/rest/api/2/myself",
+			expectedUser:     configuration.JiraUser{Key: "JIRAUSER10100", Name: "jdoe", DisplayName: "John Doe"},
+			expectedRequests: 1,
+		},
+		{
+			name:             "Cloud",
+			flavour:          configuration.FlavourCloud,
+			expectedPath:     "/rest/api/3/myself",
+			expectedUser:     configuration.JiraUser{AccountID: "5b10a2844c20165700ede21g", DisplayName: "John Doe"},
+			expectedRequests: 1,
+		},
+		{
+			name:             "Cached",
+			flavour:          configuration.FlavourServer,
+			cached:           &configuration.JiraUser{Key: "cached"},
+			expectedUser:     configuration.JiraUser{Key: "cached"},
+			expectedRequests: 0,
+		},
+		{
+			name:             "CachedButRefreshed",
+			flavour:          configuration.FlavourServer,
+			cached:           &configuration.JiraUser{Key: "cached"},
+			refresh:          true,
+			expectedPath:     "/rest/api/2/myself",
+			expectedUser:     configuration.JiraUser{Key: "JIRAUSER10100", Name: "jdoe", DisplayName: "John Doe"},
+			expectedRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				assert.Equal(t, tt.expectedPath, r.URL.Path)
+				if tt.flavour == configuration.FlavourCloud {
+					w.Write([]byte(`{"accountId": "5b10a2844c20165700ede21g", "displayName": "John Doe", "active": true}`))
+					return
+				}
+				w.Write([]byte(`{"key": "JIRAUSER10100", "name": "jdoe", "displayName": "John Doe", "active": true}`))
+			}))
+			defer server.Close()
+
+			client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+				JiraOrigin:  server.URL,
+				JiraToken:   "token123",
+				JiraEmail:   "me@example.com",
+				JiraFlavour: tt.flavour,
+				JiraUser:    tt.cached,
+			}), nil)
+			user, err := client.GetCurrentUser(context.Background(), tt.refresh)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedUser, user)
+			assert.Equal(t, tt.expectedRequests, requests)
+		})
+	}
+}
+
+func TestIsSameUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     configuration.JiraUser
+		author   JiraAuthor
+		expected bool
+	}{
+		{"AccountID", configuration.JiraUser{AccountID: "abc"}, JiraAuthor{AccountID: "abc"}, true},
+		{"DifferentAccountID", configuration.JiraUser{AccountID: "abc", Name: "jdoe"}, JiraAuthor{AccountID: "xyz", Name: "jdoe"}, false},
+		{"Key", configuration.JiraUser{Key: "JIRAUSER1", Name: "jdoe"}, JiraAuthor{Key: "JIRAUSER1"}, true},
+		{"NameIgnoringCase", configuration.JiraUser{Name: "JDoe"}, JiraAuthor{Name: "jdoe"}, true},
+		{"EmailAsLastResort", configuration.JiraUser{EmailAddress: "Me@example.com"}, JiraAuthor{Email: "me@example.com"}, true},
+		{"NothingInCommon", configuration.JiraUser{Key: "JIRAUSER1"}, JiraAuthor{AccountID: "abc"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isSameUser(tt.user, tt.author))
+		})
+	}
+}
+
+func TestGetLoggedTime_MatchesUserWithHiddenEmail(t *testing.T) {
+	started := time.Now().Format("2006-01-02T15:04:05.000-0700")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/myself":
+			w.Write([]byte(`{"key": "JIRAUSER10100", "name": "jdoe", "displayName": "John Doe"}`))
+		case "/rest/api/2/search":
+			w.Write([]byte(`{"total": 1, "issues": [{"key": "ISSUE-1", "fields": {"summary": "Fix bug"}}]}`))
+		case "/rest/api/2/issue/ISSUE-1/worklog":
+			fmt.Fprintf(w, `{"worklogs": [
+				{"author": {"key": "JIRAUSER10100", "name": "jdoe"}, "started": "%s", "timeSpentSeconds": 3600},
+				{"author": {"key": "JIRAUSER10200", "name": "other"}, "started": "%s", "timeSpentSeconds": 600}
+			]}`, started, started)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+		ServerInfo: cachedServerInfo(configuration.FlavourServer),
+	}), nil)
+	logs, err := client.GetLoggedTime(context.Background(), LoggedTimeQuery{FromDays: 1, Reporter: progress.NewSilentReporter()})
+	assert.NoError(t, err)
+	assert.Len(t, logs.Days, 1)
+	assert.Equal(t, time.Hour, logs.Days[0].TimeLogged)
+}
@@ -0,0 +1,36 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kinds of worklog visibility restrictions supported by Jira.
+const (
+	VisibilityGroup = "group"
+	VisibilityRole  = "role"
+)
+
+// Visibility restricts worklog to members of a group or a project role.
+type Visibility struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// ParseVisibility parses visibility passed as "role:Developers" or "group:security".
+func ParseVisibility(s string) (*Visibility, error) {
+	kind, value, found := strings.Cut(strings.TrimSpace(s), ":")
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	value = strings.TrimSpace(value)
+	if !found || value == "" || (kind != VisibilityGroup && kind != VisibilityRole) {
+		return nil, fmt.Errorf("%w: %q", errorInvalidVisibility, s)
+	}
+	return &Visibility{Type: kind, Value: value}, nil
+}
+
+func (v *Visibility) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.Type + ":" + v.Value
+}
@@ -0,0 +1,317 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FilipFl/logit/internal/cache"
+	"github.com/FilipFl/logit/internal/configuration"
+)
+
+// Jira lists every worklog changed on the instance, not only user's ones, so
+// after a longer break it's cheaper to rebuild the cache than to catch up.
+const maxIncrementalSyncAge = 7 * 24 * time.Hour
+
+// Jira doesn't report changes from the last minute, sync starts a bit earlier
+// to not miss them.
+const syncSafetyMargin = time.Minute
+
+// Worklogs older than that are dropped from the cache during sync.
+const maxCachedWorklogAge = 31 * 24 * time.Hour
+
+// Jira reports worklogs changed by anyone, on busy instances catching up with
+// more changes than that costs more than rebuilding the cache from search.
+const maxSyncedWorklogChanges = 5000
+
+const worklogListChunkSize = 1000
+const issueIDsChunkSize = 100
+
+type worklogChange struct {
+	WorklogID   int64 `json:"worklogId"`
+	UpdatedTime int64 `json:"updatedTime"`
+}
+
+type worklogChangesPage struct {
+	Values   []worklogChange `json:"values"`
+	Since    int64           `json:"since"`
+	Until    int64           `json:"until"`
+	LastPage bool            `json:"lastPage"`
+}
+
+// getCachedUserWorklogs returns user's worklogs from local cache after
+// bringing it up to date with changes made in Jira since the last run.
+func (c *JiraClient) getCachedUserWorklogs(ctx context.Context, user configuration.JiraUser, query LoggedTimeQuery) ([]cache.Worklog, error) {
+	now := time.Now()
+	owner := cacheOwner(c.config.GetJiraOrigin(), user)
+	coveredFrom := now.AddDate(0, 0, -(query.FromDays))
+
+	state, err := c.worklogCache.Load()
+	if err != nil || query.Refresh || !canSyncIncrementally(state, owner, coveredFrom, now) {
+		return c.rebuildWorklogCache(ctx, user, owner, query, now)
+	}
+	if err := c.syncWorklogCache(ctx, user, state); err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return c.rebuildWorklogCache(ctx, user, owner, query, now)
+	}
+	pruneWorklogCache(state, now.Add(-maxCachedWorklogAge))
+	// cache is an optimisation, failing to persist it only makes next run slower
+	c.worklogCache.Save(state)
+	return cachedEntries(state), nil
+}
+
+func canSyncIncrementally(state *cache.Worklogs, owner string, coveredFrom, now time.Time) bool {
+	return state != nil &&
+		state.Version == cache.WorklogsVersion &&
+		state.Owner == owner &&
+		!state.CoveredFrom.After(coveredFrom) &&
+		now.Sub(state.Since) < maxIncrementalSyncAge
+}
+
+func pruneWorklogCache(state *cache.Worklogs, before time.Time) {
+	for id, worklog := range state.Entries {
+		if worklog.Started.Before(before) {
+			delete(state.Entries, id)
+		}
+	}
+	if state.CoveredFrom.Before(before) {
+		state.CoveredFrom = before
+	}
+}
+
+func cacheOwner(origin string, user configuration.JiraUser) string {
+	id := user.AccountID
+	if id == "" {
+		id = user.Key
+	}
+	if id == "" {
+		id = user.Name
+	}
+	return origin + "|" + id
+}
+
+func cachedEntries(state *cache.Worklogs) []cache.Worklog {
+	worklogs := make([]cache.Worklog, 0, len(state.Entries))
+	for _, worklog := range state.Entries {
+		worklogs = append(worklogs, worklog)
+	}
+	return worklogs
+}
+
+func (c *JiraClient) rebuildWorklogCache(ctx context.Context, user configuration.JiraUser, owner string, query LoggedTimeQuery, now time.Time) ([]cache.Worklog, error) {
+	worklogs, err := c.fetchUserWorklogs(ctx, user, query.FromDays, query.Reporter)
+	if err != nil {
+		return worklogs, err
+	}
+	state := cache.NewWorklogs(owner, now.AddDate(0, 0, -(query.FromDays)), now.Add(-syncSafetyMargin))
+	for _, worklog := range worklogs {
+		state.Entries[worklog.ID] = worklog
+	}
+	c.worklogCache.Save(state)
+	return worklogs, nil
+}
+
+// cacheWrittenWorklog applies worklog this client just logged or updated to
+// the cache, as Jira reports such changes to sync up to a minute late. When the
+// worklog's issue isn't cached yet, cache is marked stale to be rebuilt.
+func (c *JiraClient) cacheWrittenWorklog(worklog JiraIssueWorklog) {
+	c.updateWorklogCache(func(state *cache.Worklogs) {
+		for _, cached := range state.Entries {
+			if cached.IssueID != worklog.IssueID {
+				continue
+			}
+			issue := JiraIssue{ID: cached.IssueID, Key: cached.IssueKey, Fields: JiraIssueFields{Summary: cached.IssueSummary}}
+			if entry, err := toCachedWorklog(worklog, issue); err == nil && entry.ID != "" {
+				state.Entries[entry.ID] = entry
+				return
+			}
+			break
+		}
+		state.Since = time.Time{}
+	})
+}
+
+// uncacheWorklog removes worklog this client just deleted from the cache.
+func (c *JiraClient) uncacheWorklog(id string) {
+	c.updateWorklogCache(func(state *cache.Worklogs) {
+		delete(state.Entries, id)
+	})
+}
+
+func (c *JiraClient) updateWorklogCache(change func(state *cache.Worklogs)) {
+	if c.worklogCache == nil {
+		return
+	}
+	state, err := c.worklogCache.Load()
+	if err != nil || state == nil {
+		return
+	}
+	change(state)
+	// cache is an optimisation, failing to persist it only makes next run slower
+	c.worklogCache.Save(state)
+}
+
+// syncWorklogCache applies worklogs updated and deleted in Jira since state.Since.
+// It gives up with errorTooManyWorklogChanges when Jira reports more than
+// maxSyncedWorklogChanges of them.
+func (c *JiraClient) syncWorklogCache(ctx context.Context, user configuration.JiraUser, state *cache.Worklogs) error {
+	updatedIDs, updatedUntil, err := c.getChangedWorklogIDs(ctx, "updated", state.Since, maxSyncedWorklogChanges)
+	if err != nil {
+		return err
+	}
+	deletedIDs, deletedUntil, err := c.getChangedWorklogIDs(ctx, "deleted", state.Since, maxSyncedWorklogChanges)
+	if err != nil {
+		return err
+	}
+	updated, err := c.getWorklogsByIDs(ctx, updatedIDs)
+	if err != nil {
+		return err
+	}
+
+	issues := map[string]JiraIssue{}
+	for _, worklog := range state.Entries {
+		issues[worklog.IssueID] = JiraIssue{ID: worklog.IssueID, Key: worklog.IssueKey, Fields: JiraIssueFields{Summary: worklog.IssueSummary}}
+	}
+	unknownIssueIDs := []string{}
+	for _, worklog := range updated {
+		if _, known := issues[worklog.IssueID]; !known && isSameUser(user, worklog.Author) {
+			unknownIssueIDs = append(unknownIssueIDs, worklog.IssueID)
+			issues[worklog.IssueID] = JiraIssue{}
+		}
+	}
+	if err := c.getIssuesByIDs(ctx, unknownIssueIDs, issues); err != nil {
+		return err
+	}
+
+	for _, worklog := range updated {
+		issue := issues[worklog.IssueID]
+		if !isSameUser(user, worklog.Author) || issue.Key == "" {
+			delete(state.Entries, worklog.ID)
+			continue
+		}
+		entry, err := toCachedWorklog(worklog, issue)
+		if err != nil {
+			continue
+		}
+		state.Entries[entry.ID] = entry
+	}
+	for _, id := range deletedIDs {
+		delete(state.Entries, id)
+	}
+
+	// changes up to the earlier of both timestamps are surely applied
+	until := updatedUntil
+	if deletedUntil.Before(until) {
+		until = deletedUntil
+	}
+	if until.After(state.Since) {
+		state.Since = until
+	}
+	return nil
+}
+
+// getChangedWorklogIDs pages through /worklog/updated or /worklog/deleted and
+// returns IDs of reported worklogs with the time changes are known up to.
+// Paging stops with errorTooManyWorklogChanges once more than limit IDs are reported.
+func (c *JiraClient) getChangedWorklogIDs(ctx context.Context, kind string, since time.Time, limit int) ([]string, time.Time, error) {
+	ids := []string{}
+	sinceMs := since.UnixMilli()
+	// with nothing reported Jira is known to be quiet until shortly before the request
+	quietUntil := time.Now().Add(-syncSafetyMargin)
+	for {
+		endpoint := c.apiPath(fmt.Sprintf("/worklog/%s?since=%d", kind, sinceMs))
+		resp, err := c.callGet(ctx, endpoint)
+		if err != nil {
+			return nil, since, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			apiErr := c.newAPIError(resp)
+			resp.Body.Close()
+			return nil, since, apiErr
+		}
+		var page worklogChangesPage
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, since, err
+		}
+
+		for _, change := range page.Values {
+			ids = append(ids, strconv.FormatInt(change.WorklogID, 10))
+		}
+		if len(ids) > limit {
+			return nil, since, errorTooManyWorklogChanges
+		}
+		if page.Until > sinceMs {
+			sinceMs = page.Until
+		}
+		if page.LastPage || len(page.Values) == 0 {
+			if len(ids) == 0 && quietUntil.After(since) {
+				return ids, quietUntil, nil
+			}
+			return ids, time.UnixMilli(sinceMs), nil
+		}
+	}
+}
+
+func (c *JiraClient) getWorklogsByIDs(ctx context.Context, ids []string) ([]JiraIssueWorklog, error) {
+	worklogs := []JiraIssueWorklog{}
+	for chunk := range slices.Chunk(ids, worklogListChunkSize) {
+		numericIDs := make([]int64, 0, len(chunk))
+		for _, id := range chunk {
+			numericID, err := strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			numericIDs = append(numericIDs, numericID)
+		}
+		jsonData, err := json.Marshal(map[string][]int64{"ids": numericIDs})
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.callPost(ctx, c.apiPath("/worklog/list"), jsonData, retryIdempotent, c.assertConfigurationIsValid)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			apiErr := c.newAPIError(resp)
+			resp.Body.Close()
+			return nil, apiErr
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errorFailedToReadBody
+		}
+		var page []JiraIssueWorklog
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, err
+		}
+		worklogs = append(worklogs, page...)
+	}
+	return worklogs, nil
+}
+
+// getIssuesByIDs resolves keys and summaries of issues with ids into issues map.
+// Issues user can't see are left out.
+func (c *JiraClient) getIssuesByIDs(ctx context.Context, ids []string, issues map[string]JiraIssue) error {
+	for chunk := range slices.Chunk(ids, issueIDsChunkSize) {
+		it := c.newSearchIterator(fmt.Sprintf("id in (%s)", strings.Join(chunk, ",")), []string{"key", "summary"}, c.assertConfigurationIsValid)
+		for it.Next(ctx) {
+			issue := it.Issue()
+			issues[issue.ID] = issue
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FilipFl/logit/internal/cache"
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/FilipFl/logit/internal/progress"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeWorklogsServer struct {
+	started         string
+	updated         string
+	deleted         string
+	list            string
+	worklogSearches int
+	syncRequests    int
+	listRequests    int
+}
+
+func (f *fakeWorklogsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/rest/api/2/search":
+		var query SearchJql
+		json.NewDecoder(r.Body).Decode(&query)
+		if strings.HasPrefix(query.JQL, "worklogAuthor") {
+			f.worklogSearches++
+			w.Write([]byte(`{"total": 1, "issues": [{"id": "10001", "key": "ISSUE-1", "fields": {"summary": "First"}}]}`))
+			return
+		}
+		if query.JQL == "id in (10002)" {
+			w.Write([]byte(`{"total": 1, "issues": [{"id": "10002", "key": "ISSUE-2", "fields": {"summary": "Second"}}]}`))
+			return
+		}
+		w.Write([]byte(`{"total": 0, "issues": []}`))
+	case "/rest/api/2/issue/ISSUE-1/worklog":
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"id": "200", "issueId": "10001", "author": {"key": "me"}, "started": "%s", "timeSpentSeconds": 1800}`, f.started)
+			return
+		}
+		fmt.Fprintf(w, `{"worklogs": [{"id": "100", "issueId": "10001", "author": {"key": "me"}, "started": "%s", "timeSpentSeconds": 3600}]}`, f.started)
+	case "/rest/api/2/issue/ISSUE-1/worklog/100":
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprintf(w, `{"id": "100", "issueId": "10001", "author": {"key": "me"}, "started": "%s", "timeSpentSeconds": 5400}`, f.started)
+	case "/rest/api/2/worklog/updated":
+		f.syncRequests++
+		w.Write([]byte(f.updated))
+	case "/rest/api/2/worklog/deleted":
+		f.syncRequests++
+		w.Write([]byte(f.deleted))
+	case "/rest/api/2/worklog/list":
+		f.listRequests++
+		w.Write([]byte(f.list))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestGetLoggedTime_WorklogCache(t *testing.T) {
+	started := time.Now().Format(jiraTimeFormat)
+	now := time.Now().UnixMilli()
+	fake := &fakeWorklogsServer{
+		started: started,
+		updated: `{"values": [], "lastPage": true}`,
+		deleted: `{"values": [], "lastPage": true}`,
+		list:    `[]`,
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	worklogCache := cache.NewMockWorklogCache()
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+		JiraUser:   &configuration.JiraUser{Key: "me"},
+	}), worklogCache)
+	query := LoggedTimeQuery{FromDays: 7, Reporter: progress.NewSilentReporter()}
+
+	// first run builds the cache from search
+	logs, err := client.GetLoggedTime(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, logs.Days[0].TimeLogged)
+	assert.Equal(t, 1, fake.worklogSearches)
+	assert.Equal(t, 0, fake.syncRequests)
+	assert.Contains(t, worklogCache.Worklogs.Entries, "100")
+	firstSince := worklogCache.Worklogs.Since
+
+	// second run applies updates reported by Jira
+	fake.updated = fmt.Sprintf(`{"values": [{"worklogId": 100, "updatedTime": %d}, {"worklogId": 101, "updatedTime": %d}, {"worklogId": 102, "updatedTime": %d}], "until": %d, "lastPage": true}`, now, now, now, now)
+	fake.list = fmt.Sprintf(`[
+		{"id": "100", "issueId": "10001", "author": {"key": "me"}, "started": "%s", "timeSpentSeconds": 7200},
+		{"id": "101", "issueId": "10002", "author": {"key": "me"}, "started": "%s", "timeSpentSeconds": 1800},
+		{"id": "102", "issueId": "10003", "author": {"key": "someone"}, "started": "%s", "timeSpentSeconds": 1800}
+	]`, started, started, started)
+	logs, err = client.GetLoggedTime(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fake.worklogSearches)
+	assert.Equal(t, 2, fake.syncRequests)
+	assert.Len(t, logs.Days[0].Worklogs, 2)
+	assert.Equal(t, "ISSUE-2", logs.Days[0].Worklogs[1].TaskKey)
+	assert.Equal(t, 2*time.Hour+30*time.Minute, logs.Days[0].TimeLogged)
+	assert.True(t, worklogCache.Worklogs.Since.After(firstSince))
+
+	// third run removes deleted worklogs
+	fake.updated = `{"values": [], "lastPage": true}`
+	fake.deleted = fmt.Sprintf(`{"values": [{"worklogId": 100, "updatedTime": %d}], "until": %d, "lastPage": true}`, now, now)
+	logs, err = client.GetLoggedTime(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Len(t, logs.Days[0].Worklogs, 1)
+	assert.Equal(t, 30*time.Minute, logs.Days[0].TimeLogged)
+
+	// refresh rebuilds cache from scratch
+	query.Refresh = true
+	logs, err = client.GetLoggedTime(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fake.worklogSearches)
+	assert.Equal(t, time.Hour, logs.Days[0].TimeLogged)
+}
+
+func TestGetLoggedTime_WorklogCacheSeesOwnWrites(t *testing.T) {
+	fake := &fakeWorklogsServer{
+		started: time.Now().Format(jiraTimeFormat),
+		// Jira doesn't report changes from the last minute yet
+		updated: `{"values": [], "lastPage": true}`,
+		deleted: `{"values": [], "lastPage": true}`,
+		list:    `[]`,
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	worklogCache := cache.NewMockWorklogCache()
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+		JiraUser:   &configuration.JiraUser{Key: "me"},
+	}), worklogCache)
+	query := LoggedTimeQuery{FromDays: 7, Reporter: progress.NewSilentReporter()}
+	_, err := client.GetLoggedTime(context.Background(), query)
+	assert.NoError(t, err)
+
+	id, err := client.LogTime(context.Background(), "ISSUE-1", 30*time.Minute, time.Now(), "", LogTimeOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "200", id)
+	logs, err := client.GetLoggedTime(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour+30*time.Minute, logs.Days[0].TimeLogged)
+	assert.Equal(t, "First", logs.Days[0].Worklogs[0].Summary)
+
+	timeSpent := 90 * time.Minute
+	assert.NoError(t, client.UpdateWorklog(context.Background(), "ISSUE-1", "100", WorklogUpdate{TimeSpent: &timeSpent}))
+	logs, err = client.GetLoggedTime(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, logs.Days[0].TimeLogged)
+
+	assert.NoError(t, client.DeleteWorklog(context.Background(), "ISSUE-1", "100", EstimateAdjustment{}))
+	logs, err = client.GetLoggedTime(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, logs.Days[0].TimeLogged)
+	assert.Equal(t, 1, fake.worklogSearches)
+}
+
+func TestGetLoggedTime_TooManyChangesRebuildsCache(t *testing.T) {
+	now := time.Now().UnixMilli()
+	fake := &fakeWorklogsServer{
+		started: time.Now().Format(jiraTimeFormat),
+		updated: `{"values": [], "lastPage": true}`,
+		deleted: `{"values": [], "lastPage": true}`,
+		list:    `[]`,
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	worklogCache := cache.NewMockWorklogCache()
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin: server.URL,
+		JiraToken:  "token123",
+		JiraUser:   &configuration.JiraUser{Key: "me"},
+	}), worklogCache)
+	query := LoggedTimeQuery{FromDays: 7, Reporter: progress.NewSilentReporter()}
+	_, err := client.GetLoggedTime(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fake.worklogSearches)
+
+	// busy instance keeps reporting full pages of changes made by others
+	changes := make([]string, worklogListChunkSize)
+	for i := range changes {
+		changes[i] = fmt.Sprintf(`{"worklogId": %d, "updatedTime": %d}`, 1000+i, now)
+	}
+	fake.updated = fmt.Sprintf(`{"values": [%s], "until": %d, "lastPage": false}`, strings.Join(changes, ","), now)
+	logs, err := client.GetLoggedTime(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, logs.Days[0].TimeLogged)
+	assert.Equal(t, maxSyncedWorklogChanges/worklogListChunkSize+1, fake.syncRequests)
+	assert.Equal(t, 0, fake.listRequests)
+	assert.Equal(t, 2, fake.worklogSearches)
+}
+
+func TestCanSyncIncrementally(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	state := cache.NewWorklogs("origin|me", now.AddDate(0, 0, -14), now.Add(-time.Hour))
+
+	assert.True(t, canSyncIncrementally(state, "origin|me", now.AddDate(0, 0, -7), now))
+	assert.False(t, canSyncIncrementally(nil, "origin|me", now.AddDate(0, 0, -7), now))
+	assert.False(t, canSyncIncrementally(state, "origin|someone", now.AddDate(0, 0, -7), now))
+	assert.False(t, canSyncIncrementally(state, "origin|me", now.AddDate(0, 0, -20), now))
+	assert.False(t, canSyncIncrementally(state, "origin|me", now.AddDate(0, 0, -7), now.AddDate(0, 0, 8)))
+}
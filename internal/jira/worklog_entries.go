@@ -0,0 +1,156 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// WorklogEntry is a single worklog of the current user.
+type WorklogEntry struct {
+	ID         string
+	IssueID    string
+	TaskKey    string
+	Summary    string
+	Started    time.Time
+	TimeSpent  time.Duration
+	Comment    string
+	Visibility *Visibility
+}
+
+// WorklogUpdate lists worklog fields to change, nil fields are left untouched.
+type WorklogUpdate struct {
+	TimeSpent *time.Duration
+	Started   *time.Time
+	Comment   *string
+}
+
+func toWorklogEntry(log JiraIssueWorklog, issue JiraIssue) (WorklogEntry, error) {
+	started, err := time.Parse(jiraTimeFormat, log.Started)
+	if err != nil {
+		return WorklogEntry{}, err
+	}
+	return WorklogEntry{
+		ID:         log.ID,
+		IssueID:    issue.ID,
+		TaskKey:    issue.Key,
+		Summary:    issue.Fields.Summary,
+		Started:    started,
+		TimeSpent:  time.Duration(log.TimeSpentSeconds) * time.Second,
+		Comment:    string(log.Comment),
+		Visibility: log.Visibility,
+	}, nil
+}
+
+// GetWorklog finds worklog by its ID without knowing the issue it belongs to.
+func (c *JiraClient) GetWorklog(ctx context.Context, worklogID string) (WorklogEntry, error) {
+	worklogs, err := c.getWorklogsByIDs(ctx, []string{worklogID})
+	if err != nil {
+		return WorklogEntry{}, err
+	}
+	if len(worklogs) == 0 {
+		return WorklogEntry{}, fmt.Errorf("%w: %s", errorWorklogNotFound, worklogID)
+	}
+	issues := map[string]JiraIssue{}
+	if err := c.getIssuesByIDs(ctx, []string{worklogs[0].IssueID}, issues); err != nil {
+		return WorklogEntry{}, err
+	}
+	issue, found := issues[worklogs[0].IssueID]
+	if !found {
+		return WorklogEntry{}, fmt.Errorf("%w: %s", errorWorklogNotFound, worklogID)
+	}
+	return toWorklogEntry(worklogs[0], issue)
+}
+
+// GetWorklogsOn returns current user's worklogs started on the same day as day.
+func (c *JiraClient) GetWorklogsOn(ctx context.Context, day time.Time) ([]WorklogEntry, error) {
+	user, err := c.GetCurrentUser(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errorIdentifyingUser, err)
+	}
+	date := day.Format(time.DateOnly)
+	it := c.newSearchIterator(
+		fmt.Sprintf(`worklogAuthor = currentUser() AND worklogDate = "%s"`, date),
+		[]string{"key", "summary", worklogField},
+		c.assertConfigurationIsValid,
+	)
+	issues, err := collectIssues(ctx, it)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errorFetchingWorklogs, err)
+	}
+
+	entries := []WorklogEntry{}
+	sinceDay := time.Since(day) + 24*time.Hour
+	for fetched := range c.fetchIssuesWorklogs(ctx, issues, sinceDay) {
+		if fetched.err != nil {
+			return nil, fmt.Errorf("%w: %w", errorFetchingWorklogs, fetched.err)
+		}
+		for _, log := range fetched.worklogs {
+			if !isSameUser(user, log.Author) {
+				continue
+			}
+			entry, err := toWorklogEntry(log, fetched.issue)
+			if err != nil || entry.Started.In(day.Location()).Format(time.DateOnly) != date {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+	}
+	slices.SortFunc(entries, func(a, b WorklogEntry) int {
+		return a.Started.Compare(b.Started)
+	})
+	return entries, nil
+}
+
+func (c *JiraClient) UpdateWorklog(ctx context.Context, taskKey, worklogID string, update WorklogUpdate) error {
+	payload := map[string]any{}
+	if update.TimeSpent != nil {
+		payload["timeSpentSeconds"] = int(update.TimeSpent.Seconds())
+	}
+	if update.Started != nil {
+		payload["started"] = update.Started.Format(jiraTimeFormat)
+	}
+	if update.Comment != nil {
+		payload["comment"] = c.richText(*update.Comment)
+	}
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	endpoint := c.apiPath(fmt.Sprintf("/issue/%s/worklog/%s", taskKey, worklogID))
+	resp, err := c.callPut(ctx, endpoint, jsonData)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to update worklog: %w", c.newAPIError(resp))
+	}
+	var updated JiraIssueWorklog
+	// worklog is updated already, unreadable response only makes cache stale
+	json.NewDecoder(resp.Body).Decode(&updated)
+	c.cacheWrittenWorklog(updated)
+	return nil
+}
+
+func (c *JiraClient) DeleteWorklog(ctx context.Context, taskKey, worklogID string, adjustment EstimateAdjustment) error {
+	if err := adjustment.Validate(); err != nil {
+		return err
+	}
+	endpoint := c.apiPath(fmt.Sprintf("/issue/%s/worklog/%s", taskKey, worklogID)) + adjustment.query("increaseBy")
+	resp, err := c.callDelete(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to delete worklog: %w", c.newAPIError(resp))
+	}
+	c.uncacheWorklog(worklogID)
+	return nil
+}
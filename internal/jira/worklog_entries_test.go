@@ -0,0 +1,191 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/FilipFl/logit/internal/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateWorklog(t *testing.T) {
+	started := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	duration := 2 * time.Hour
+	comment := "Fixed estimate"
+	tests := []struct {
+		name            string
+		update          WorklogUpdate
+		status          int
+		expectedPayload map[string]any
+		expectError     bool
+	}{
+		{
+			name:            "DurationOnly",
+			update:          WorklogUpdate{TimeSpent: &duration},
+			status:          http.StatusOK,
+			expectedPayload: map[string]any{"timeSpentSeconds": float64(7200)},
+		},
+		{
+			name:   "AllFields",
+			update: WorklogUpdate{TimeSpent: &duration, Started: &started, Comment: &comment},
+			status: http.StatusOK,
+			expectedPayload: map[string]any{
+				"timeSpentSeconds": float64(7200),
+				"started":          "2026-10-16T09:30:00.000+0000",
+				"comment":          "Fixed estimate",
+			},
+		},
+		{
+			name:            "Rejected",
+			update:          WorklogUpdate{Comment: &comment},
+			status:          http.StatusForbidden,
+			expectedPayload: map[string]any{"comment": "Fixed estimate"},
+			expectError:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "PUT", r.Method)
+				assert.Equal(t, "/rest/api/2/issue/TEST-1/worklog/100", r.URL.Path)
+				payload := map[string]any{}
+				json.NewDecoder(r.Body).Decode(&payload)
+				assert.Equal(t, tt.expectedPayload, payload)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+				JiraOrigin: server.URL,
+				JiraToken:  "token123",
+			}), nil)
+			err := client.UpdateWorklog(context.Background(), "TEST-1", "100", tt.update)
+			if tt.expectError {
+				var apiErr *APIError
+				assert.ErrorAs(t, err, &apiErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetWorklog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/worklog/list":
+			w.Write([]byte(`[{"id": "100", "issueId": "10001", "started": "2026-10-16T09:30:00.000+0000", "timeSpentSeconds": 1800,
+				"comment": {"type": "doc", "version": 1, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Review"}]}]}}]`))
+		case "/rest/api/3/search/jql":
+			w.Write([]byte(`{"isLast": true, "issues": [{"id": "10001", "key": "TEST-1", "fields": {"summary": "First"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+		JiraOrigin:  server.URL,
+		JiraToken:   "token123",
+		JiraEmail:   "me@example.com",
+		JiraFlavour: configuration.FlavourCloud,
+	}), nil)
+	entry, err := client.GetWorklog(context.Background(), "100")
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST-1", entry.TaskKey)
+	assert.Equal(t, "10001", entry.IssueID)
+	assert.Equal(t, 30*time.Minute, entry.TimeSpent)
+	assert.Equal(t, "Review", entry.Comment)
+}
+
+func TestRichText_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected RichText
+	}{
+		{name: "PlainString", input: `"Some comment"`, expected: "Some comment"},
+		{name: "ADFDocument", input: `{"type": "doc", "version": 1, "content": [
+			{"type": "paragraph", "content": [{"type": "text", "text": "First"}]},
+			{"type": "paragraph", "content": [{"type": "text", "text": "Second"}]}]}`, expected: "First\nSecond"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var text RichText
+			assert.NoError(t, json.Unmarshal([]byte(tt.input), &text))
+			assert.Equal(t, tt.expected, text)
+		})
+	}
+}
+
+func TestDeleteWorklog(t *testing.T) {
+	tests := []struct {
+		name          string
+		adjustment    EstimateAdjustment
+		expectedQuery string
+		expectedError error
+	}{
+		{
+			name:          "DefaultAdjustment",
+			adjustment:    EstimateAdjustment{},
+			expectedQuery: "",
+		},
+		{
+			name:          "Leave",
+			adjustment:    EstimateAdjustment{Mode: AdjustEstimateLeave},
+			expectedQuery: "adjustEstimate=leave",
+		},
+		{
+			name:          "NewEstimate",
+			adjustment:    EstimateAdjustment{Mode: AdjustEstimateNew, NewEstimate: "2d 4h"},
+			expectedQuery: "adjustEstimate=new&newEstimate=2d+4h",
+		},
+		{
+			name:          "Manual",
+			adjustment:    EstimateAdjustment{Mode: AdjustEstimateManual, Amount: "1h"},
+			expectedQuery: "adjustEstimate=manual&increaseBy=1h",
+		},
+		{
+			name:          "ManualWithoutAmount",
+			adjustment:    EstimateAdjustment{Mode: AdjustEstimateManual},
+			expectedError: errorEstimateAmountRequired,
+		},
+		{
+			name:          "UnknownMode",
+			adjustment:    EstimateAdjustment{Mode: "sometimes"},
+			expectedError: errorInvalidAdjustEstimate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				assert.Equal(t, "DELETE", r.Method)
+				assert.Equal(t, "/rest/api/2/issue/TEST-1/worklog/100", r.URL.Path)
+				assert.Equal(t, tt.expectedQuery, r.URL.RawQuery)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client := NewJiraClient(configuration.NewMockConfig(&configuration.Cfg{
+				JiraOrigin: server.URL,
+				JiraToken:  "token123",
+			}), nil)
+			err := client.DeleteWorklog(context.Background(), "TEST-1", "100", tt.adjustment)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Equal(t, 0, requests)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 1, requests)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package jira
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"time"
 )
@@ -10,6 +11,8 @@ type TaskLog struct {
 	Summary    string
 	LoggedTime time.Duration
 	TaskKey    string
+	// Visibilities lists restrictions of task's worklogs, e.g. "role:Developers".
+	Visibilities []string
 }
 
 func (d *TaskLog) StringLoggedTime() string {
@@ -30,6 +33,11 @@ func (t *Logs) AddLog(worklog TaskLog, started time.Time) {
 				for _, taskWorklog := range day.Worklogs {
 					if taskWorklog.TaskKey == worklog.TaskKey {
 						taskWorklog.LoggedTime += worklog.LoggedTime
+						for _, visibility := range worklog.Visibilities {
+							if !slices.Contains(taskWorklog.Visibilities, visibility) {
+								taskWorklog.Visibilities = append(taskWorklog.Visibilities, visibility)
+							}
+						}
 						day.TimeLogged += worklog.LoggedTime
 						return false
 					}
@@ -0,0 +1,160 @@
+package oauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	requestTokenPath = "/plugins/servlet/oauth/request-token"
+	authorizePath    = "/plugins/servlet/oauth/authorize"
+	accessTokenPath  = "/plugins/servlet/oauth/access-token"
+	callbackPath     = "/callback"
+)
+
+// Token is OAuth token with its secret, either temporary request token or access token.
+type Token struct {
+	Token  string
+	Secret string
+}
+
+// RequestToken obtains temporary token user authorizes in the browser.
+func RequestToken(ctx context.Context, client *http.Client, origin string, signer *Signer, callback string) (Token, error) {
+	return postForToken(ctx, client, origin+requestTokenPath, signer, "", map[string]string{"oauth_callback": callback})
+}
+
+// AuthorizeURL is page where user approves access of request token.
+func AuthorizeURL(origin string, requestToken Token) string {
+	return fmt.Sprintf("%s%s?oauth_token=%s", origin, authorizePath, url.QueryEscape(requestToken.Token))
+}
+
+// AccessToken exchanges authorized request token for access token.
+func AccessToken(ctx context.Context, client *http.Client, origin string, signer *Signer, requestToken Token, verifier string) (Token, error) {
+	return postForToken(ctx, client, origin+accessTokenPath, signer, requestToken.Token, map[string]string{"oauth_verifier": verifier})
+}
+
+func postForToken(ctx context.Context, client *http.Client, endpoint string, signer *Signer, token string, extra map[string]string) (Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return Token{}, err
+	}
+	if err := signer.Sign(req, token, extra); err != nil {
+		return Token{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Token{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Token{}, err
+	}
+	values, _ := url.ParseQuery(string(body))
+	if resp.StatusCode != http.StatusOK {
+		// Jira describes OAuth failures with oauth_problem, e.g. consumer_key_unknown
+		if problem := values.Get("oauth_problem"); problem != "" {
+			return Token{}, fmt.Errorf("%w: %s", errorTokenRequestFailed, problem)
+		}
+		return Token{}, fmt.Errorf("%w: %s", errorTokenRequestFailed, resp.Status)
+	}
+	if values.Get("oauth_token") == "" {
+		return Token{}, errorNoTokenInResponse
+	}
+	return Token{Token: values.Get("oauth_token"), Secret: values.Get("oauth_token_secret")}, nil
+}
+
+// CallbackListener receives verifier Jira redirects browser with once user
+// approves access.
+type CallbackListener struct {
+	listener net.Listener
+	server   *http.Server
+	results  chan callbackResult
+
+	mu           sync.Mutex
+	requestToken string
+}
+
+type callbackResult struct {
+	verifier string
+	err      error
+}
+
+// ListenForCallback starts listening on random port of loopback interface.
+func ListenForCallback() (*CallbackListener, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	l := &CallbackListener{listener: listener, results: make(chan callbackResult, 1)}
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, l.handle)
+	l.server = &http.Server{Handler: mux}
+	go l.server.Serve(listener)
+	return l, nil
+}
+
+// URL is callback URL passed to Jira when requesting token.
+func (l *CallbackListener) URL() string {
+	return "http://" + l.listener.Addr().String() + callbackPath
+}
+
+// Expect makes listener accept only redirects for requestToken. It has to be
+// called before user is sent to authorize the token, until then every
+// redirect is rejected.
+func (l *CallbackListener) Expect(requestToken Token) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.requestToken = requestToken.Token
+}
+
+// Wait blocks until Jira redirects back or ctx is done.
+func (l *CallbackListener) Wait(ctx context.Context) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case result := <-l.results:
+		return result.verifier, result.err
+	}
+}
+
+func (l *CallbackListener) Close() error {
+	return l.server.Close()
+}
+
+func (l *CallbackListener) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	// anything on this machine can reach the port, only redirect for our token counts
+	l.mu.Lock()
+	expected := l.requestToken
+	l.mu.Unlock()
+	token := r.URL.Query().Get("oauth_token")
+	if expected == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+		http.Error(w, "unknown request token", http.StatusForbidden)
+		return
+	}
+	verifier := r.URL.Query().Get("oauth_verifier")
+	result := callbackResult{verifier: verifier}
+	// Jira redirects with verifier "denied" when user rejects access
+	if verifier == "" || strings.EqualFold(verifier, "denied") {
+		result = callbackResult{err: errorAuthorizationDenied}
+		fmt.Fprintln(w, "logit was not authorized, You can close this page.")
+	} else {
+		fmt.Fprintln(w, "logit is authorized, You can close this page and return to terminal.")
+	}
+	select {
+	case l.results <- result:
+	default:
+	}
+}
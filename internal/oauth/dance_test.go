@@ -0,0 +1,118 @@
+package oauth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDance(t *testing.T) {
+	signer, _ := newTestSigner(t)
+	callback, err := ListenForCallback()
+	assert.NoError(t, err)
+	defer callback.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		params := authorizationParams(t, r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case requestTokenPath:
+			assert.Equal(t, callback.URL(), params["oauth_callback"])
+			assert.Empty(t, params["oauth_token"])
+			w.Write([]byte("oauth_token=request123&oauth_token_secret=requestSecret&oauth_callback_confirmed=true"))
+		case accessTokenPath:
+			assert.Equal(t, "request123", params["oauth_token"])
+			assert.Equal(t, "verifier123", params["oauth_verifier"])
+			w.Write([]byte("oauth_token=access123&oauth_token_secret=accessSecret"))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	requestToken, err := RequestToken(context.Background(), server.Client(), server.URL, signer, callback.URL())
+	assert.NoError(t, err)
+	assert.Equal(t, Token{Token: "request123", Secret: "requestSecret"}, requestToken)
+	assert.Equal(t, server.URL+"/plugins/servlet/oauth/authorize?oauth_token=request123", AuthorizeURL(server.URL, requestToken))
+	callback.Expect(requestToken)
+
+	// browser redirected by Jira after user approved access
+	resp, err := http.Get(callback.URL() + "?oauth_token=request123&oauth_verifier=verifier123")
+	assert.NoError(t, err)
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	verifier, err := callback.Wait(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "verifier123", verifier)
+
+	accessToken, err := AccessToken(context.Background(), server.Client(), server.URL, signer, requestToken, verifier)
+	assert.NoError(t, err)
+	assert.Equal(t, Token{Token: "access123", Secret: "accessSecret"}, accessToken)
+}
+
+func TestRequestToken_Problem(t *testing.T) {
+	signer, _ := newTestSigner(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("oauth_problem=consumer_key_unknown"))
+	}))
+	defer server.Close()
+
+	_, err := RequestToken(context.Background(), server.Client(), server.URL, signer, "oob")
+	assert.ErrorIs(t, err, errorTokenRequestFailed)
+	assert.ErrorContains(t, err, "consumer_key_unknown")
+}
+
+func TestCallbackListener_Denied(t *testing.T) {
+	callback, err := ListenForCallback()
+	assert.NoError(t, err)
+	defer callback.Close()
+	callback.Expect(Token{Token: "request123"})
+
+	resp, err := http.Get(callback.URL() + "?oauth_token=request123&oauth_verifier=denied")
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	_, err = callback.Wait(context.Background())
+	assert.ErrorIs(t, err, errorAuthorizationDenied)
+}
+
+func TestCallbackListener_RejectsForeignRequests(t *testing.T) {
+	callback, err := ListenForCallback()
+	assert.NoError(t, err)
+	defer callback.Close()
+
+	// redirect before token is known
+	resp, err := http.Get(callback.URL() + "?oauth_token=request123&oauth_verifier=early")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	callback.Expect(Token{Token: "request123"})
+	resp, err = http.Get(callback.URL() + "?oauth_token=other&oauth_verifier=forged")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, err = http.Post(callback.URL()+"?oauth_token=request123&oauth_verifier=posted", "text/plain", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, err = http.Get(callback.URL() + "?oauth_token=request123&oauth_verifier=verifier123")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	verifier, err := callback.Wait(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "verifier123", verifier)
+}
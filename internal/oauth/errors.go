@@ -0,0 +1,8 @@
+package oauth
+
+import "errors"
+
+var errorLoadingPrivateKey = errors.New("failed to load OAuth private key")
+var errorTokenRequestFailed = errors.New("Jira refused OAuth token request")
+var errorNoTokenInResponse = errors.New("no OAuth token in Jira response")
+var errorAuthorizationDenied = errors.New("OAuth authorization denied")
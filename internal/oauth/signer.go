@@ -0,0 +1,139 @@
+package oauth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const signatureMethod = "RSA-SHA1"
+
+// Signer signs requests with OAuth 1.0a RSA-SHA1, the only signature method
+// Jira application links accept.
+type Signer struct {
+	consumerKey string
+	key         *rsa.PrivateKey
+	now         func() time.Time
+	nonce       func() string
+}
+
+func NewSigner(consumerKey string, key *rsa.PrivateKey) *Signer {
+	return &Signer{consumerKey: consumerKey, key: key, now: time.Now, nonce: randomNonce}
+}
+
+// LoadPrivateKey reads PEM encoded RSA key, either PKCS #1 or PKCS #8.
+func LoadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errorLoadingPrivateKey, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM data found in %s", errorLoadingPrivateKey, path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errorLoadingPrivateKey, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: key in %s is not RSA key", errorLoadingPrivateKey, path)
+	}
+	return key, nil
+}
+
+// Sign sets Authorization header of req. Token is empty when requesting
+// temporary token, extra holds protocol parameters like oauth_callback.
+func (s *Signer) Sign(req *http.Request, token string, extra map[string]string) error {
+	params := map[string]string{
+		"oauth_consumer_key":     s.consumerKey,
+		"oauth_nonce":            s.nonce(),
+		"oauth_signature_method": signatureMethod,
+		"oauth_timestamp":        strconv.FormatInt(s.now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	if token != "" {
+		params["oauth_token"] = token
+	}
+	for name, value := range extra {
+		params[name] = value
+	}
+	digest := sha1.Sum([]byte(signatureBase(req, params)))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA1, digest[:])
+	if err != nil {
+		return err
+	}
+	params["oauth_signature"] = base64.StdEncoding.EncodeToString(signature)
+
+	header := make([]string, 0, len(params))
+	for name, value := range params {
+		header = append(header, fmt.Sprintf(`%s="%s"`, percentEncode(name), percentEncode(value)))
+	}
+	sort.Strings(header)
+	req.Header.Set("Authorization", "OAuth "+strings.Join(header, ", "))
+	return nil
+}
+
+// signatureBase builds signature base string as described in RFC 5849 3.4.1.
+// Logit sends JSON bodies only, so body never takes part in the signature.
+func signatureBase(req *http.Request, oauthParams map[string]string) string {
+	pairs := []string{}
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			pairs = append(pairs, percentEncode(name)+"="+percentEncode(value))
+		}
+	}
+	for name, value := range oauthParams {
+		pairs = append(pairs, percentEncode(name)+"="+percentEncode(value))
+	}
+	sort.Strings(pairs)
+	return strings.Join([]string{
+		strings.ToUpper(req.Method),
+		percentEncode(baseURL(req.URL)),
+		percentEncode(strings.Join(pairs, "&")),
+	}, "&")
+}
+
+func baseURL(u *url.URL) string {
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && !(scheme == "http" && port == "80") && !(scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	return scheme + "://" + host + u.EscapedPath()
+}
+
+// percentEncode encodes everything but unreserved characters, as RFC 5849 3.6 requires.
+func percentEncode(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func randomNonce() string {
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	return hex.EncodeToString(nonce)
+}
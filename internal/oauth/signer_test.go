@@ -0,0 +1,116 @@
+package oauth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSigner(t *testing.T) (*Signer, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	signer := NewSigner("logit", key)
+	signer.now = func() time.Time { return time.Unix(1700000000, 0) }
+	signer.nonce = func() string { return "nonce123" }
+	return signer, key
+}
+
+// authorizationParams parses OAuth Authorization header.
+func authorizationParams(t *testing.T, header string) map[string]string {
+	assert.True(t, strings.HasPrefix(header, "OAuth "))
+	params := map[string]string{}
+	for _, pair := range strings.Split(strings.TrimPrefix(header, "OAuth "), ", ") {
+		name, value, _ := strings.Cut(pair, "=")
+		unquoted, err := url.PathUnescape(strings.Trim(value, `"`))
+		assert.NoError(t, err)
+		params[name] = unquoted
+	}
+	return params
+}
+
+func TestSign(t *testing.T) {
+	signer, key := newTestSigner(t)
+	req, err := http.NewRequest(http.MethodGet, "https://Jira.Example:443/rest/api/2/search?jql=project%20%3D%20ABC&maxResults=50", nil)
+	assert.NoError(t, err)
+
+	assert.NoError(t, signer.Sign(req, "access123", nil))
+
+	params := authorizationParams(t, req.Header.Get("Authorization"))
+	assert.Equal(t, "logit", params["oauth_consumer_key"])
+	assert.Equal(t, "access123", params["oauth_token"])
+	assert.Equal(t, "RSA-SHA1", params["oauth_signature_method"])
+	assert.Equal(t, "1700000000", params["oauth_timestamp"])
+
+	signature, err := base64.StdEncoding.DecodeString(params["oauth_signature"])
+	assert.NoError(t, err)
+	base := "GET&https%3A%2F%2Fjira.example%2Frest%2Fapi%2F2%2Fsearch&" +
+		"jql%3Dproject%2520%253D%2520ABC%26maxResults%3D50%26oauth_consumer_key%3Dlogit%26oauth_nonce%3Dnonce123%26" +
+		"oauth_signature_method%3DRSA-SHA1%26oauth_timestamp%3D1700000000%26oauth_token%3Daccess123%26oauth_version%3D1.0"
+	digest := sha1.Sum([]byte(base))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA1, digest[:], signature))
+}
+
+func TestPercentEncode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "abc-._~123", expected: "abc-._~123"},
+		{input: "a b+c", expected: "a%20b%2Bc"},
+		{input: "http://127.0.0.1:8080/callback", expected: "http%3A%2F%2F127.0.0.1%3A8080%2Fcallback"},
+		{input: "ż*", expected: "%C5%BC%2A"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, percentEncode(tt.input))
+		})
+	}
+}
+
+func TestLoadPrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	write := func(name, blockType string, der []byte) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "PKCS1", path: write("pkcs1.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key))},
+		{name: "PKCS8", path: write("pkcs8.pem", "PRIVATE KEY", pkcs8)},
+		{name: "NotKey", path: write("garbage.pem", "PRIVATE KEY", []byte("garbage")), wantErr: true},
+		{name: "Missing", path: filepath.Join(dir, "missing.pem"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, err := LoadPrivateKey(tt.path)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errorLoadingPrivateKey)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, key.Equal(loaded))
+		})
+	}
+}
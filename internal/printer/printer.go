@@ -11,3 +11,9 @@ func PrintGreen(s string) {
 	fmt.Print(s)
 	color.Unset()
 }
+
+func PrintYellow(s string) {
+	color.Set(color.FgHiYellow)
+	fmt.Print(s)
+	color.Unset()
+}
@@ -0,0 +1,48 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+)
+
+type BasicReporter struct {
+	out   io.Writer
+	label string
+	total int
+	done  int
+}
+
+func NewBasicReporter(out io.Writer, label string) *BasicReporter {
+	return &BasicReporter{out: out, label: label}
+}
+
+func (r *BasicReporter) Start(total int) {
+	r.total = total
+	r.done = 0
+	r.print()
+}
+
+func (r *BasicReporter) Increment() {
+	r.done++
+	r.print()
+}
+
+func (r *BasicReporter) Finish() {
+	fmt.Fprintln(r.out)
+}
+
+func (r *BasicReporter) print() {
+	fmt.Fprintf(r.out, "\r%s %d/%d", r.label, r.done, r.total)
+}
+
+type SilentReporter struct{}
+
+func NewSilentReporter() *SilentReporter {
+	return &SilentReporter{}
+}
+
+func (r *SilentReporter) Start(total int) {}
+
+func (r *SilentReporter) Increment() {}
+
+func (r *SilentReporter) Finish() {}
@@ -0,0 +1,23 @@
+package progress
+
+type MockReporter struct {
+	Total    int
+	Done     int
+	Finished bool
+}
+
+func NewMockReporter() *MockReporter {
+	return &MockReporter{}
+}
+
+func (r *MockReporter) Start(total int) {
+	r.Total = total
+}
+
+func (r *MockReporter) Increment() {
+	r.Done++
+}
+
+func (r *MockReporter) Finish() {
+	r.Finished = true
+}
@@ -0,0 +1,7 @@
+package progress
+
+type Reporter interface {
+	Start(total int)
+	Increment()
+	Finish()
+}
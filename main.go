@@ -1,6 +1,13 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/FilipFl/logit/internal/cache"
 	"github.com/FilipFl/logit/internal/commands"
 	"github.com/FilipFl/logit/internal/configuration"
 	"github.com/FilipFl/logit/internal/git"
@@ -11,13 +18,45 @@ import (
 )
 
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	prompter := prompter.NewBasicPrompter()
 	config := configuration.NewBasicConfig()
 	gitHandler := git.NewBasicGitHandler()
 	timer := timer.NewBasicTimer()
-	jiraClient := jira.NewJiraClient(config)
+	worklogCache := cache.NewBasicWorklogCache()
+	tempoClient := jira.NewTempoClient(config, worklogCache)
+	var jiraClient jira.Client = tempoClient.JiraClient
+	if config.GetBackend() == configuration.BackendTempo {
+		jiraClient = tempoClient
+	}
 
-	var rootCmd = &cobra.Command{Use: "logit"}
+	var tracer *jira.Tracer
+	var rootCmd = &cobra.Command{
+		Use: "logit",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			debug, _ := cmd.Flags().GetBool("debug")
+			traceFile, _ := cmd.Flags().GetString("trace-file")
+			if !debug && traceFile == "" {
+				return
+			}
+			var debugOut io.Writer
+			if debug {
+				debugOut = os.Stderr
+			}
+			tracer = jira.NewTracer(debugOut, traceFile != "")
+			tempoClient.SetTracer(tracer)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			traceFile, _ := cmd.Flags().GetString("trace-file")
+			if traceFile != "" {
+				saveTrace(tracer, traceFile)
+			}
+		},
+	}
+	rootCmd.PersistentFlags().Bool("debug", false, "Log Jira requests with bodies to stderr with credentials redacted")
+	rootCmd.PersistentFlags().String("trace-file", "", "Save Jira requests and responses as HAR-like JSON file with credentials redacted")
 
 	var configCmd = &cobra.Command{
 		Use:   "config",
@@ -29,11 +68,40 @@ func main() {
 		Short: "Manage aliases",
 	}
 
+	var timesheetCmd = &cobra.Command{
+		Use:   "timesheet",
+		Short: "Manage Tempo timesheet approvals",
+	}
+
+	var queryCmd = &cobra.Command{
+		Use:   "query",
+		Short: "Manage saved JQL queries",
+	}
+
+	var sprintCmd = &cobra.Command{
+		Use:   "sprint",
+		Short: "Show sprints of configured board",
+	}
+
+	var worklogCmd = &cobra.Command{
+		Use:   "worklog",
+		Short: "Manage logged work",
+	}
+
 	setHostCmd := configuration.NewSetOriginCommand(config)
 	setTokenCmd := configuration.NewSetTokenCommand(config)
 	setTokenEnvNameCmd := configuration.NewSetTokenEnvNameCommand(config)
 	setEmailCmd := configuration.NewSetEmailCommand(config)
-	initCmd := configuration.NewInitCommand(config, prompter)
+	setTimeoutCmd := configuration.NewSetTimeoutCommand(config)
+	setFlavourCmd := configuration.NewSetFlavourCommand(config)
+	setBackendCmd := configuration.NewSetBackendCommand(config)
+	setParallelismCmd := configuration.NewSetParallelismCommand(config)
+	setTasksJQLCmd := configuration.NewSetTasksJQLCommand(config)
+	setBoardCmd := configuration.NewSetBoardCommand(config)
+	setConnectionCmd := configuration.NewSetConnectionCommand(config, prompter, jira.ValidateConnection)
+	setAuthModeCmd := configuration.NewSetAuthModeCommand(config)
+	setUsernameCmd := configuration.NewSetUsernameCommand(config)
+	initCmd := configuration.NewInitCommand(config, prompter, tempoClient.HTTPClient)
 	trustGitBranchCmd := configuration.NewSwitchTrustGitBranchCommand(config)
 	showConfigCmd := configuration.NewShowConfigCommand(config)
 
@@ -41,18 +109,57 @@ func main() {
 	removeAliasCmd := commands.NewRemoveAliasCommand(config)
 	listAliasesCmd := commands.NewListAliasesCommand(config)
 
+	setQueryCmd := commands.NewSetQueryCommand(config, prompter)
+	removeQueryCmd := commands.NewRemoveQueryCommand(config)
+	listQueriesCmd := commands.NewListQueriesCommand(config)
+
 	startTimerCmd := commands.NewStartTimerCommand(config, timer)
 	openCmd := commands.NewOpenCommand(config, prompter, gitHandler)
 
-	myTasksCmd := commands.NewMyTasksCommand(jiraClient)
+	myTasksCmd := commands.NewMyTasksCommand(config, jiraClient)
 	myWorklogsCmd := commands.NewMyWorklogsCommand(jiraClient)
+	whoAmICmd := commands.NewWhoAmICommand(jiraClient)
 	logCmd := commands.NewLogCommand(config, prompter, gitHandler, timer, jiraClient)
+	transitionCmd := commands.NewTransitionCommand(config, prompter, gitHandler, jiraClient)
+	commentCmd := commands.NewCommentCommand(config, prompter, gitHandler, timer, jiraClient)
+	createCmd := commands.NewCreateCommand(config, prompter, timer, jiraClient)
+	showCmd := commands.NewShowCommand(config, prompter, gitHandler, jiraClient)
+	searchCmd := commands.NewSearchCommand(config, jiraClient)
+	listFiltersCmd := commands.NewListFiltersCommand(jiraClient)
+	editWorklogCmd := commands.NewEditWorklogCommand(config, prompter, timer, jiraClient)
+	deleteWorklogCmd := commands.NewDeleteWorklogCommand(config, prompter, timer, jiraClient)
+	sprintTasksCmd := commands.NewSprintTasksCommand(config, jiraClient)
+	sprintTimeCmd := commands.NewSprintTimeCommand(config, jiraClient)
+	timesheetStatusCmd := commands.NewTimesheetStatusCommand(config, timer, tempoClient)
+	timesheetSubmitCmd := commands.NewTimesheetSubmitCommand(config, prompter, timer, tempoClient)
 
-	configCmd.AddCommand(setHostCmd, setTokenCmd, setTokenEnvNameCmd, setEmailCmd, initCmd, trustGitBranchCmd, showConfigCmd)
+	configCmd.AddCommand(setHostCmd, setTokenCmd, setTokenEnvNameCmd, setEmailCmd, setAuthModeCmd, setUsernameCmd, setTimeoutCmd, setFlavourCmd, setBackendCmd, setParallelismCmd, setTasksJQLCmd, setBoardCmd, setConnectionCmd, initCmd, trustGitBranchCmd, showConfigCmd)
 
 	aliasCmd.AddCommand(setAliasCmd, listAliasesCmd, removeAliasCmd)
 
-	rootCmd.AddCommand(configCmd, logCmd, startTimerCmd, aliasCmd, myTasksCmd, myWorklogsCmd, openCmd)
+	queryCmd.AddCommand(setQueryCmd, listQueriesCmd, removeQueryCmd)
+
+	worklogCmd.AddCommand(editWorklogCmd, deleteWorklogCmd)
+
+	sprintCmd.AddCommand(sprintTasksCmd, sprintTimeCmd)
+
+	timesheetCmd.AddCommand(timesheetStatusCmd, timesheetSubmitCmd)
 
-	rootCmd.Execute()
+	rootCmd.AddCommand(configCmd, logCmd, startTimerCmd, aliasCmd, myTasksCmd, myWorklogsCmd, openCmd, whoAmICmd, worklogCmd, timesheetCmd, transitionCmd, commentCmd, createCmd, showCmd, searchCmd, listFiltersCmd, queryCmd, sprintCmd)
+
+	rootCmd.ExecuteContext(ctx)
+}
+
+func saveTrace(tracer *jira.Tracer, path string) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed saving trace:", err)
+		return
+	}
+	defer file.Close()
+	if err := tracer.WriteHAR(file); err != nil {
+		fmt.Fprintln(os.Stderr, "Failed saving trace:", err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, "Trace saved to", path)
 }